@@ -2,11 +2,16 @@ package baseapp
 
 import (
 	"context"
+	"time"
 
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/job"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/telemetry/health"
+	"github.com/berachain/offchain-sdk/tools/leader"
+	"github.com/berachain/offchain-sdk/worker"
 
 	ethdb "github.com/ethereum/go-ethereum/ethdb"
 )
@@ -24,6 +29,15 @@ type BaseApp struct {
 
 	// svr is the server for the baseapp.
 	svr *server.Server
+
+	// elector (optional) elects the replica that runs the producers of singleton jobs.
+	elector *leader.Elector
+
+	// health is the registry of health checks, which are reported through metrics at
+	// healthReportInterval (if non-zero).
+	health               *health.Registry
+	metrics              telemetry.Metrics
+	healthReportInterval time.Duration
 }
 
 // New creates a new baseapp.
@@ -32,18 +46,24 @@ func New(
 	logger log.Logger,
 	ethClient eth.Client,
 	jobs []job.Basic,
+	executionCfgs map[string]job.ExecutionConfig,
+	workersCfg worker.Config,
 	db ethdb.KeyValueStore,
 	svr *server.Server,
+	metrics telemetry.Metrics,
 ) *BaseApp {
 	return &BaseApp{
 		name:   name,
 		logger: logger,
 		jobMgr: NewManager(
 			jobs,
+			executionCfgs,
+			workersCfg,
 			&contextFactory{
 				connPool: ethClient,
 				logger:   logger,
 				db:       db,
+				metrics:  metrics,
 			},
 		),
 		svr: svr,
@@ -55,6 +75,22 @@ func (b *BaseApp) Logger() log.Logger {
 	return b.logger.With("namespace", "baseapp")
 }
 
+// setElector sets the elector of the replica that runs the producers of singleton jobs.
+func (b *BaseApp) setElector(elector *leader.Elector) {
+	b.elector = elector
+	b.jobMgr.setElector(elector)
+}
+
+// JobManager returns the job manager of the baseapp, e.g. to control jobs at runtime.
+func (b *BaseApp) JobManager() *JobManager {
+	return b.jobMgr
+}
+
+// Health returns the registry of health checks of the baseapp.
+func (b *BaseApp) Health() *health.Registry {
+	return b.health
+}
+
 // Start starts the baseapp.
 func (b *BaseApp) Start(ctx context.Context) error {
 	b.Logger().Info("attempting to start")
@@ -64,6 +100,16 @@ func (b *BaseApp) Start(ctx context.Context) error {
 	b.jobMgr.Start(ctx)
 	b.jobMgr.RunProducers(ctx)
 
+	// Start the leader election, which starts the producers of singleton jobs once elected.
+	if b.elector != nil {
+		go b.elector.Run(ctx)
+	}
+
+	// Report the health checks through metrics, if enabled.
+	if b.health != nil && b.metrics != nil && b.healthReportInterval > 0 {
+		go b.health.ReportMetrics(ctx, b.metrics, b.healthReportInterval)
+	}
+
 	if b.svr == nil {
 		b.Logger().Info("no HTTP server registered, skipping")
 	} else {
@@ -78,7 +124,9 @@ func (b *BaseApp) Stop() {
 	b.Logger().Info("attempting to stop")
 	defer b.Logger().Info("successfully stopped")
 
-	b.jobMgr.Stop()
+	if err := b.jobMgr.Stop(); err != nil {
+		b.Logger().Error("error stopping job manager", "err", err)
+	}
 	if b.svr != nil {
 		b.svr.Stop()
 	}
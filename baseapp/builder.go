@@ -2,11 +2,17 @@ package baseapp
 
 import (
 	"errors"
+	"time"
 
 	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/config"
 	"github.com/berachain/offchain-sdk/job"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/telemetry/health"
+	"github.com/berachain/offchain-sdk/tools/leader"
+	"github.com/berachain/offchain-sdk/worker"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	ethdb "github.com/ethereum/go-ethereum/ethdb"
@@ -16,9 +22,19 @@ import (
 type AppBuilder struct {
 	appName   string
 	jobs      []job.Basic
+	jobCfgs   map[string]job.ExecutionConfig
+	workers   worker.Config
 	db        ethdb.KeyValueStore
 	ethClient eth.Client
 	svr       *server.Server
+	metrics   telemetry.Metrics
+
+	jobControl bool
+	elector    *leader.Elector
+	shutdown   config.ShutdownConfig
+
+	health               *health.Registry
+	healthReportInterval time.Duration
 }
 
 // NewAppBuilder creates a new app builder.
@@ -26,6 +42,7 @@ func NewAppBuilder(appName string) *AppBuilder {
 	return &AppBuilder{
 		appName: appName,
 		jobs:    []job.Basic{},
+		health:  health.NewRegistry(),
 	}
 }
 
@@ -39,11 +56,29 @@ func (ab *AppBuilder) RegisterJob(job job.Basic) {
 	ab.jobs = append(ab.jobs, job)
 }
 
+// RegisterJobConfigs registers the execution configs of jobs, by registry key, which override
+// those declared by the jobs.
+func (ab *AppBuilder) RegisterJobConfigs(cfgs map[string]job.ExecutionConfig) {
+	ab.jobCfgs = cfgs
+}
+
+// RegisterWorkers registers the config of the worker pools, which must be valid (see
+// worker.Config.Validate).
+func (ab *AppBuilder) RegisterWorkers(cfg worker.Config) {
+	ab.workers = cfg
+}
+
 // RegisterDB registers the db.
 func (ab *AppBuilder) RegisterDB(db ethdb.KeyValueStore) {
 	ab.db = db
 }
 
+// RegisterMetrics registers the metrics, to which the job manager records job executions and
+// which jobs can access from their context.
+func (ab *AppBuilder) RegisterMetrics(metrics telemetry.Metrics) {
+	ab.metrics = metrics
+}
+
 // RegisterHTTPServer registers the http server.
 func (ab *AppBuilder) RegisterHTTPServer(svr *server.Server) {
 	ab.svr = svr
@@ -78,6 +113,42 @@ func (ab *AppBuilder) RegisterPrometheusTelemetry() error {
 	return ab.RegisterHTTPHandler(&server.Handler{Path: "/metrics", Handler: promhttp.Handler()})
 }
 
+// RegisterJobControl registers the job control HTTP handlers (see JobManager.Handlers), to list,
+// pause, resume and trigger jobs.
+func (ab *AppBuilder) RegisterJobControl() error {
+	if ab.svr == nil {
+		return errors.New("must enable the HTTP server to register job control")
+	}
+
+	ab.jobControl = true
+	return nil
+}
+
+// RegisterLeaderElector registers the leader elector, so that the producers of singleton jobs
+// (see job.ExecutionConfig) only run on the replica elected leader.
+func (ab *AppBuilder) RegisterLeaderElector(elector *leader.Elector) {
+	ab.elector = elector
+}
+
+// RegisterShutdown registers the config of the shutdown sequence of the job manager.
+func (ab *AppBuilder) RegisterShutdown(cfg config.ShutdownConfig) {
+	ab.shutdown = cfg
+}
+
+// Health returns the registry of health checks, to which the components of the app can register
+// their checks. The checks of the job manager, of the jobs that check their own health (e.g. the
+// transactor), of the eth client and of the db are registered when building the app.
+func (ab *AppBuilder) Health() *health.Registry {
+	return ab.health
+}
+
+// RegisterHealthReportInterval registers the interval at which the health checks are reported as
+// gauges through the registered metrics (see health.Registry.ReportMetrics). If 0, the health
+// checks are not reported.
+func (ab *AppBuilder) RegisterHealthReportInterval(interval time.Duration) {
+	ab.healthReportInterval = interval
+}
+
 // RegisterEthClient registers the eth client.
 // TODO: update this to connection pool on baseapp and context gets one for running
 func (ab *AppBuilder) RegisterEthClient(ethClient eth.Client) {
@@ -88,12 +159,41 @@ func (ab *AppBuilder) RegisterEthClient(ethClient eth.Client) {
 func (ab *AppBuilder) BuildApp(
 	logger log.Logger,
 ) *BaseApp {
-	return New(
+	app := New(
 		ab.appName,
 		logger,
 		ab.ethClient,
 		ab.jobs,
+		ab.jobCfgs,
+		ab.workers,
 		ab.db,
 		ab.svr,
+		ab.metrics,
 	)
+
+	app.jobMgr.shutdownCfg = ab.shutdown
+	app.jobMgr.setHealth(ab.health)
+	registerHealthChecks(ab.health, ab.ethClient, ab.db)
+	app.health, app.metrics, app.healthReportInterval = ab.health, ab.metrics, ab.healthReportInterval
+	if ab.svr != nil {
+		for _, handler := range ab.health.Handlers() {
+			ab.svr.RegisterHandler(handler)
+		}
+		for _, j := range ab.jobs {
+			if hh, ok := j.(server.HasHandlers); ok {
+				for _, handler := range hh.Handlers() {
+					ab.svr.RegisterHandler(handler)
+				}
+			}
+		}
+	}
+	if ab.elector != nil {
+		app.setElector(ab.elector)
+	}
+	if ab.jobControl {
+		for _, handler := range app.JobManager().Handlers() {
+			ab.svr.RegisterHandler(handler)
+		}
+	}
+	return app
 }
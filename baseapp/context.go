@@ -5,6 +5,7 @@ import (
 
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
 	sdk "github.com/berachain/offchain-sdk/types"
 
 	ethdb "github.com/ethereum/go-ethereum/ethdb"
@@ -15,6 +16,7 @@ type contextFactory struct {
 	connPool eth.Client
 	logger   log.Logger
 	db       ethdb.KeyValueStore
+	metrics  telemetry.Metrics
 }
 
 // NewContextFactory creates a new context from a given context.Context.
@@ -24,5 +26,5 @@ func (cf *contextFactory) NewSDKContext(ctx context.Context) *sdk.Context {
 		cf.connPool,
 		cf.logger,
 		cf.db,
-	)
+	).WithMetrics(cf.metrics)
 }
@@ -0,0 +1,271 @@
+package baseapp
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	workertypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/worker"
+)
+
+// Compile time check to ensure that jobExecutor can be passed to job producers as a worker pool,
+// which also submits the executions of downstream consumers to their own executors.
+var (
+	_ job.WorkerPool       = (*jobExecutor)(nil)
+	_ job.JobSubmitter     = (*jobExecutor)(nil)
+	_ workertypes.Observer = (*jobExecutor)(nil)
+)
+
+// invalidPromChars matches the characters that are not allowed in prometheus metric names.
+var invalidPromChars = regexp.MustCompile(`[^a-zA-Z0-9_]`) //nolint:gochecknoglobals // regexp.
+
+// jobExecutor submits the executions of a job to a worker pool, according to the job's execution
+// config.
+type jobExecutor struct {
+	jm   *JobManager
+	pool *worker.Pool
+
+	// state is the runtime state of the job (nil for unregistered jobs), which pauses submissions
+	// while the job is paused.
+	state *jobState
+
+	// inflight tracks the submitted executions, which are waited for when the job is removed or
+	// on shutdown, and inflightCount counts them.
+	inflight      sync.WaitGroup
+	inflightCount atomic.Int64
+
+	// slots limits the number of submitted executions, if the job has a max concurrency.
+	slots chan struct{}
+
+	// orderingKey returns the key of an execution's input, if executions are serial. Executions
+	// with the same key are run one at a time, in order, by running them all in one worker.
+	orderingKey func(args any) string
+	mu          sync.Mutex
+	pending     map[string][]func()
+}
+
+// Submit implements job.WorkerPool.
+func (e *jobExecutor) Submit(task func()) {
+	e.submit(nil, task)
+}
+
+// SubmitAndWait implements job.WorkerPool.
+func (e *jobExecutor) SubmitAndWait(task func()) {
+	done := make(chan struct{})
+	if !e.submit(nil, func() {
+		defer close(done)
+		task()
+	}) {
+		return
+	}
+	select {
+	case <-done:
+	case <-e.jm.ctx.Done():
+	}
+}
+
+// SubmitJob implements job.JobSubmitter, submitting the execution to the given job's executor.
+// Downstream executions are submitted even while the job manager is shutting down, so that
+// in-flight pipelines are drained.
+func (e *jobExecutor) SubmitJob(j workertypes.Executable, args any, execute func()) {
+	consumer := e.jm.executorFor(j)
+	if consumer.state != nil && !consumer.state.waitResumed(e.jm.ctx) {
+		return
+	}
+	consumer.dispatch(args, execute)
+}
+
+// ObserveExecution implements workertypes.Observer.
+func (e *jobExecutor) ObserveExecution(j workertypes.Executable, start time.Time, err error) {
+	e.jm.ObserveExecution(j, start, err)
+}
+
+// submit submits the execution of the job with the given input, once the job is not paused.
+// While the job manager is shutting down, it blocks the (producer) caller until the producers
+// are cancelled, then rejects the execution, returning false. Executions submitted while the
+// producer is being stopped are rejected too.
+func (e *jobExecutor) submit(args any, task func()) bool {
+	if e.state != nil && !e.state.waitResumed(e.jm.submitCtx) && e.jm.submitCtx.Err() == nil {
+		return false
+	}
+	if e.jm.submitCtx.Err() != nil {
+		if e.jm.runCtx != nil {
+			<-e.jm.runCtx.Done()
+		}
+		e.jm.rejected.Add(1)
+		return false
+	}
+	e.dispatch(args, task)
+	return true
+}
+
+// dispatch submits the execution of the job with the given input to the pool, according to the
+// job's execution config.
+func (e *jobExecutor) dispatch(args any, task func()) {
+	e.inflight.Add(1)
+	e.inflightCount.Add(1)
+	execute := task
+	task = func() {
+		defer e.inflight.Done()
+		defer e.inflightCount.Add(-1)
+		execute()
+	}
+
+	if e.slots != nil {
+		select {
+		case e.slots <- struct{}{}:
+		case <-e.jm.ctx.Done():
+			e.inflightCount.Add(-1)
+			e.inflight.Done()
+			return
+		}
+		execute := task
+		task = func() {
+			defer func() { <-e.slots }()
+			execute()
+		}
+	}
+
+	if e.orderingKey == nil {
+		e.pool.Submit(task)
+		return
+	}
+
+	key := e.orderingKey(args)
+	e.mu.Lock()
+	if queued, running := e.pending[key]; running {
+		e.pending[key] = append(queued, task)
+		e.mu.Unlock()
+		return
+	}
+	e.pending[key] = nil
+	e.mu.Unlock()
+	e.pool.Submit(func() { e.runSerial(key, task) })
+}
+
+// runSerial runs the given execution, then every execution queued with the same key, in order.
+func (e *jobExecutor) runSerial(key string, task func()) {
+	for {
+		task()
+
+		e.mu.Lock()
+		queued := e.pending[key]
+		if len(queued) == 0 {
+			delete(e.pending, key)
+			e.mu.Unlock()
+			return
+		}
+		task, e.pending[key] = queued[0], queued[1:]
+		e.mu.Unlock()
+	}
+}
+
+// executorFor returns the executor of the given job, creating it (and its dedicated pool, if any)
+// on first use.
+func (jm *JobManager) executorFor(j workertypes.Executable) *jobExecutor {
+	var (
+		key string
+		cfg job.ExecutionConfig
+	)
+	if keyed, ok := workertypes.As[interface{ RegistryKey() string }](j); ok {
+		key = keyed.RegistryKey()
+		cfg = jm.executionConfig(j, key)
+	}
+
+	jm.executorsMu.Lock()
+	defer jm.executorsMu.Unlock()
+	if e, ok := jm.executors[key]; ok {
+		return e
+	}
+
+	e := &jobExecutor{jm: jm, pool: jm.jobExecutors}
+	if state, err := jm.state(key); err == nil {
+		e.state = state
+	}
+	if cfg.Pool != "" {
+		e.pool = jm.dedicatedPool(cfg.Pool)
+	}
+	if cfg.MaxConcurrency > 0 {
+		e.slots = make(chan struct{}, cfg.MaxConcurrency)
+	}
+	if cfg.Serial {
+		e.pending = make(map[string][]func())
+		e.orderingKey = func(any) string { return "" }
+		if oj, ok := workertypes.As[job.HasOrderingKey](j); ok {
+			e.orderingKey = oj.OrderingKey
+		}
+	}
+	jm.executors[key] = e
+	return e
+}
+
+// removeExecutor removes the executor of the job with the given registry key, returning it (or
+// nil if it has none).
+func (jm *JobManager) removeExecutor(key string) *jobExecutor {
+	jm.executorsMu.Lock()
+	defer jm.executorsMu.Unlock()
+	e := jm.executors[key]
+	delete(jm.executors, key)
+	return e
+}
+
+// wait waits for the submitted executions to finish, or for the context to be done.
+func (e *jobExecutor) wait(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.inflight.Wait()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+	}
+}
+
+// executionConfig returns the execution config of the job with the given registry key: the one
+// in the app config file if any (matched case-insensitively, as config keys are lower-cased),
+// otherwise the one declared by the job.
+func (jm *JobManager) executionConfig(j workertypes.Executable, key string) job.ExecutionConfig {
+	for name, cfg := range jm.executionCfgs {
+		if strings.EqualFold(name, key) {
+			return cfg
+		}
+	}
+	if hec, ok := workertypes.As[job.HasExecutionConfig](j); ok {
+		return hec.ExecutionConfig()
+	}
+	return job.ExecutionConfig{}
+}
+
+// dedicatedPool returns the dedicated pool with the given name, creating it on first use from its
+// config in the `Workers.Pools` section of the app config file (if any). Must be called with
+// executorsMu held.
+func (jm *JobManager) dedicatedPool(name string) *worker.Pool {
+	if pool, ok := jm.dedicatedPools[name]; ok {
+		return pool
+	}
+	defaults := worker.DefaultPoolConfig()
+	defaults.Name = executorName + "-" + name
+	defaults.PrometheusPrefix = executorPromName + "_" +
+		invalidPromChars.ReplaceAllString(name, "_")
+	cfg, _ := jm.workersCfg.Pool(name)
+	pool := worker.NewPool(jm.ctx, jm.ctxFactory.logger, cfg.WithDefaults(defaults))
+	jm.dedicatedPools[name] = pool
+	return pool
+}
+
+// stopDedicatedPools stops the dedicated pools, waiting for their workers to finish.
+func (jm *JobManager) stopDedicatedPools() {
+	jm.executorsMu.Lock()
+	defer jm.executorsMu.Unlock()
+	for _, pool := range jm.dedicatedPools {
+		pool.StopAndWait()
+	}
+	jm.dedicatedPools = make(map[string]*worker.Pool)
+	jm.executors = make(map[string]*jobExecutor)
+}
@@ -0,0 +1,105 @@
+package baseapp
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// input is the input of an execution of orderedJob.
+type input struct {
+	key string
+	seq int
+}
+
+// orderedJob is a job, ordered by the key of its input, that records the order of its executions
+// and the max number of concurrent executions.
+type orderedJob struct {
+	cfg job.ExecutionConfig
+
+	mu       sync.Mutex
+	executed map[string][]int
+	running  atomic.Int32
+	peak     atomic.Int32
+}
+
+func (*orderedJob) RegistryKey() string { return "ordered" }
+
+func (j *orderedJob) ExecutionConfig() job.ExecutionConfig { return j.cfg }
+
+func (*orderedJob) OrderingKey(args any) string { return args.(input).key }
+
+func (j *orderedJob) Execute(_ context.Context, args any) (any, error) {
+	running := j.running.Add(1)
+	defer j.running.Add(-1)
+	for peak := j.peak.Load(); running > peak && !j.peak.CompareAndSwap(peak, running); {
+		peak = j.peak.Load()
+	}
+	time.Sleep(time.Millisecond)
+
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	in := args.(input)
+	j.executed[in.key] = append(j.executed[in.key], in.seq)
+	return nil, nil
+}
+
+func newTestManager(t *testing.T, prefix string, jobs ...job.Basic) *JobManager {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	logger := log.NewLogger(os.Stdout, "test-runner")
+	jm := NewManager(jobs, nil, worker.Config{}, &contextFactory{logger: logger})
+	cfg := worker.DefaultPoolConfig()
+	cfg.PrometheusPrefix = prefix
+	jm.ctx, jm.submitCtx = ctx, ctx
+	jm.jobExecutors = worker.NewPool(ctx, logger, cfg)
+	return jm
+}
+
+func TestExecutorSerialPerKey(t *testing.T) {
+	jm := newTestManager(t, "test_serial")
+	j := &orderedJob{cfg: job.ExecutionConfig{Serial: true}, executed: map[string][]int{}}
+	executor := jm.executorFor(j)
+
+	const n = 20
+	for seq := 0; seq < n; seq++ {
+		for _, key := range []string{"a", "b"} {
+			in := input{key, seq}
+			executor.submit(in, func() { _, _ = j.Execute(context.Background(), in) })
+		}
+	}
+	jm.jobExecutors.StopAndWait()
+
+	for _, key := range []string{"a", "b"} {
+		require.Len(t, j.executed[key], n)
+		for seq, executed := range j.executed[key] {
+			require.Equal(t, seq, executed)
+		}
+	}
+	require.LessOrEqual(t, j.peak.Load(), int32(2))
+}
+
+func TestExecutorMaxConcurrency(t *testing.T) {
+	jm := newTestManager(t, "test_max_concurrency")
+	j := &orderedJob{cfg: job.ExecutionConfig{MaxConcurrency: 2}, executed: map[string][]int{}}
+	executor := jm.executorFor(j)
+
+	for seq := 0; seq < 20; seq++ {
+		in := input{"a", seq}
+		executor.Submit(func() { _, _ = j.Execute(context.Background(), in) })
+	}
+	jm.jobExecutors.StopAndWait()
+
+	require.Len(t, j.executed["a"], 20)
+	require.LessOrEqual(t, j.peak.Load(), int32(2))
+}
@@ -0,0 +1,105 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/telemetry/health"
+
+	ethdb "github.com/ethereum/go-ethereum/ethdb"
+)
+
+// Names of the health checks registered by the baseapp.
+const (
+	healthCheckProducers     = "job-producers"
+	healthCheckSubscriptions = "job-subscriptions"
+	healthCheckEth           = "eth"
+	healthCheckDB            = "db"
+	healthCheckJobPrefix     = "job:"
+)
+
+// healthKey is the key read from the db to check its health.
+var healthKey = []byte("health") //nolint:gochecknoglobals // key.
+
+// CheckProducers is a liveness check that fails if the producer of a job returned while the job
+// manager is running (e.g. because of an error).
+func (jm *JobManager) CheckProducers(context.Context) error {
+	if jm.submitCtx == nil || jm.submitCtx.Err() != nil {
+		return nil
+	}
+	var stopped []string
+	for _, status := range jm.Jobs() {
+		if status.Status == JobStatusStopped {
+			stopped = append(stopped, status.Key)
+		}
+	}
+	if len(stopped) > 0 {
+		return fmt.Errorf("job producers stopped: %v", stopped)
+	}
+	return nil
+}
+
+// CheckSubscriptions is a readiness check that fails if the job manager is not running, or if a
+// subscription job is not currently subscribed.
+func (jm *JobManager) CheckSubscriptions(context.Context) error {
+	switch {
+	case jm.submitCtx == nil:
+		return ErrNotStarted
+	case jm.submitCtx.Err() != nil:
+		return ErrStopping
+	}
+
+	jm.statesMu.RLock()
+	defer jm.statesMu.RUnlock()
+	var errs []error
+	for key, s := range jm.states {
+		s.mu.Lock()
+		if s.subErr != nil && !s.stopped && !s.standby {
+			errs = append(errs, fmt.Errorf("%s: %w", key, s.subErr))
+		}
+		s.mu.Unlock()
+	}
+	return errors.Join(errs...)
+}
+
+// setHealth sets the registry of health checks, registering the checks of the job manager and of
+// the registered jobs that check their own health (e.g. the transactor).
+func (jm *JobManager) setHealth(reg *health.Registry) {
+	jm.health = reg
+	reg.Register(healthCheckProducers, health.Liveness, jm.CheckProducers)
+	reg.Register(healthCheckSubscriptions, health.Readiness, jm.CheckSubscriptions)
+	for _, j := range jm.jobRegistry.Iterate() {
+		jm.registerHealth(j)
+	}
+}
+
+// registerHealth registers the health check of the given job, if it checks its own health.
+func (jm *JobManager) registerHealth(j job.Basic) {
+	if checker, ok := j.(health.Checker); ok && jm.health != nil {
+		jm.health.Register(healthCheckJobPrefix+j.RegistryKey(), health.Readiness,
+			checker.CheckHealth)
+	}
+}
+
+// unregisterHealth unregisters the health check of the job with the given registry key, if any.
+func (jm *JobManager) unregisterHealth(key string) {
+	if jm.health != nil {
+		jm.health.Unregister(healthCheckJobPrefix + key)
+	}
+}
+
+// registerHealthChecks registers the health checks of the eth client (if it checks its own
+// health) and of the db (if any).
+func registerHealthChecks(reg *health.Registry, ethClient any, db ethdb.KeyValueStore) {
+	if checker, ok := ethClient.(health.Checker); ok {
+		reg.Register(healthCheckEth, health.Readiness, checker.CheckHealth)
+	}
+	if db != nil {
+		reg.Register(healthCheckDB, health.Readiness, func(context.Context) error {
+			_, err := db.Has(healthKey)
+			return err
+		})
+	}
+}
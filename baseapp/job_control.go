@@ -0,0 +1,325 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	workertypes "github.com/berachain/offchain-sdk/job/types"
+)
+
+// Compile time check to ensure that the job manager observes the executions of jobs.
+var _ workertypes.Observer = (*JobManager)(nil)
+
+// Statuses of a job.
+const (
+	JobStatusRunning = "running"
+	JobStatusPaused  = "paused"
+	JobStatusStopped = "stopped"
+	JobStatusStandby = "standby"
+)
+
+var (
+	// ErrJobNotFound is returned when there is no job with the given registry key.
+	ErrJobNotFound = errors.New("job not found")
+	// ErrNotStarted is returned when triggering a job before the job manager is started.
+	ErrNotStarted = errors.New("job manager not started")
+	// ErrStopping is returned when triggering a job while the job manager is shutting down.
+	ErrStopping = errors.New("job manager is shutting down")
+)
+
+// JobStatus is the status and execution statistics of a job.
+type JobStatus struct {
+	Key       string     `json:"key"`
+	Type      string     `json:"type"`
+	Status    string     `json:"status"`
+	LastRun   *time.Time `json:"lastRun,omitempty"`
+	LastError string     `json:"lastError,omitempty"`
+	Runs      uint64     `json:"runs"`
+	Failures  uint64     `json:"failures"`
+}
+
+// jobState is the runtime state of a job.
+type jobState struct {
+	j   job.Basic
+	typ string
+
+	mu sync.Mutex
+	// cancel cancels the context of the job's producer, and done is closed when it returns.
+	cancel context.CancelFunc
+	done   chan struct{}
+	// resumed is closed when a paused job is resumed; it is nil while the job is not paused.
+	resumed chan struct{}
+	// stopping is closed when the job's producer is being stopped, which unblocks it if the job is
+	// paused, without resuming the job.
+	stopping chan struct{}
+	stopped bool
+	standby bool
+	// removing is set while the job is being removed, so that its producer is not restarted (e.g.
+	// on a leader change). Guarded by the job manager's registryMu.
+	removing bool
+	// subErr is the error of the job's subscription, if it is a subscription job that is not
+	// currently subscribed.
+	subErr   error
+	lastRun  time.Time
+	lastErr  error
+	runs     uint64
+	failures uint64
+}
+
+// newJobState returns the state of the given job.
+func newJobState(j job.Basic, typ string) *jobState {
+	return &jobState{j: j, typ: typ}
+}
+
+// setRunning records the cancel function of the context of the job's producer, and the channel
+// closed when it returns.
+func (s *jobState) setRunning(cancel context.CancelFunc, done chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel, s.done, s.stopped, s.standby = cancel, done, false, false
+	s.stopping = make(chan struct{})
+}
+
+// stop cancels the context of the job's producer, unblocking it if the job is paused, and returns
+// the channel closed when it returns (nil if it is not running).
+func (s *jobState) stop() <-chan struct{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+	select {
+	case <-s.stopping:
+	default:
+		close(s.stopping)
+	}
+	return s.done
+}
+
+// setStandby marks the job as waiting for this replica to be elected leader. Its producer, if
+// running, must be stopped separately (see stopProducer); it is reported as standby meanwhile.
+func (s *jobState) setStandby() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.standby = true
+}
+
+// running returns whether the job's producer is running.
+func (s *jobState) running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.done == nil {
+		return false
+	}
+	select {
+	case <-s.done:
+		return false
+	default:
+		return true
+	}
+}
+
+// waitResumed blocks while the job is paused, returning false if the context is done or the
+// job's producer is stopped first.
+func (s *jobState) waitResumed(ctx context.Context) bool {
+	s.mu.Lock()
+	resumed, stopping := s.resumed, s.stopping
+	s.mu.Unlock()
+	if resumed == nil {
+		return true
+	}
+	select {
+	case <-resumed:
+		return true
+	case <-stopping:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// status returns the status of the job.
+func (s *jobState) status() JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := JobStatus{
+		Key:      s.j.RegistryKey(),
+		Type:     s.typ,
+		Status:   JobStatusRunning,
+		Runs:     s.runs,
+		Failures: s.failures,
+	}
+	switch {
+	case s.standby:
+		status.Status = JobStatusStandby
+	case s.stopped:
+		status.Status = JobStatusStopped
+	case s.resumed != nil:
+		status.Status = JobStatusPaused
+	}
+	if !s.lastRun.IsZero() {
+		lastRun := s.lastRun
+		status.LastRun = &lastRun
+	}
+	if s.lastErr != nil {
+		status.LastError = s.lastErr.Error()
+	}
+	return status
+}
+
+// Jobs returns the statuses of all jobs (including the downstream consumers of registered jobs),
+// ordered by registry key.
+func (jm *JobManager) Jobs() []JobStatus {
+	jm.statesMu.RLock()
+	statuses := make([]JobStatus, 0, len(jm.states))
+	for _, s := range jm.states {
+		statuses = append(statuses, s.status())
+	}
+	jm.statesMu.RUnlock()
+
+	sort.Slice(statuses, func(i, k int) bool { return statuses[i].Key < statuses[k].Key })
+	return statuses
+}
+
+// Job returns the status of the job with the given registry key.
+func (jm *JobManager) Job(key string) (JobStatus, error) {
+	s, err := jm.state(key)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	return s.status(), nil
+}
+
+// Pause pauses the job with the given registry key: its producer blocks on the next execution it
+// submits, until the job is resumed. Executions already submitted are not affected.
+func (jm *JobManager) Pause(key string) error {
+	s, err := jm.state(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resumed == nil {
+		s.resumed = make(chan struct{})
+	}
+	return nil
+}
+
+// Resume resumes the (paused) job with the given registry key.
+func (jm *JobManager) Resume(key string) error {
+	s, err := jm.state(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.resumed != nil {
+		close(s.resumed)
+		s.resumed = nil
+	}
+	return nil
+}
+
+// Trigger submits a one-off execution of the job with the given registry key, with the given
+// input, even if the job is paused.
+func (jm *JobManager) Trigger(key string, input any) error {
+	s, err := jm.state(key)
+	if err != nil {
+		return err
+	}
+	if jm.ctx == nil {
+		return ErrNotStarted
+	}
+	if jm.submitCtx.Err() != nil {
+		return ErrStopping
+	}
+
+	executor := jm.executorFor(s.j)
+	ctx := jm.ctxFactory.NewSDKContext(jm.ctx)
+	executor.dispatch(input, workertypes.NewPayload(ctx, s.j, input).
+		WithSubmitter(executor.SubmitJob).WithObserver(jm).Execute)
+	return nil
+}
+
+// ObserveExecution implements workertypes.Observer, recording the execution to the statistics of
+// the job.
+func (jm *JobManager) ObserveExecution(j workertypes.Executable, start time.Time, err error) {
+	keyed, ok := workertypes.As[interface{ RegistryKey() string }](j)
+	if !ok {
+		return
+	}
+	s, stateErr := jm.state(keyed.RegistryKey())
+	if stateErr != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastRun, s.lastErr = start, err
+	s.runs++
+	if err != nil {
+		s.failures++
+	}
+}
+
+// state returns the state of the job with the given registry key.
+func (jm *JobManager) state(key string) (*jobState, error) {
+	jm.statesMu.RLock()
+	defer jm.statesMu.RUnlock()
+	s, ok := jm.states[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, key)
+	}
+	return s, nil
+}
+
+// removeState removes the state of the job with the given registry key.
+func (jm *JobManager) removeState(key string) {
+	jm.statesMu.Lock()
+	defer jm.statesMu.Unlock()
+	delete(jm.states, key)
+}
+
+// addState adds the state of the given job.
+func (jm *JobManager) addState(j job.Basic, typ string) {
+	jm.statesMu.Lock()
+	defer jm.statesMu.Unlock()
+	jm.states[j.RegistryKey()] = newJobState(j, typ)
+}
+
+// setStopped marks the job with the given registry key as stopped, i.e. its producer returned.
+func (jm *JobManager) setStopped(key string) {
+	if s, err := jm.state(key); err == nil {
+		s.mu.Lock()
+		s.stopped = true
+		s.mu.Unlock()
+	}
+}
+
+// jobType returns the type of the given job, as run by the job manager.
+func jobType(j job.Basic) string {
+	switch j.(type) {
+	case job.HasProducer:
+		return "custom"
+	case job.Scheduled:
+		return "scheduled"
+	case job.Conditional:
+		return "conditional"
+	case job.Polling:
+		return "polling"
+	case job.Subscribable:
+		return "subscribable"
+	case job.EthSubscribable:
+		return "eth-subscribable"
+	case job.BlockHeaderSub:
+		return "block-header"
+	default:
+		return "basic"
+	}
+}
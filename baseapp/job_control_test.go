@@ -0,0 +1,111 @@
+package baseapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// echoJob is a basic job that sends its inputs on a channel, failing on "fail".
+type echoJob struct {
+	inputs chan any
+}
+
+func (*echoJob) RegistryKey() string { return "echo" }
+
+func (j *echoJob) Execute(_ context.Context, args any) (any, error) {
+	j.inputs <- args
+	if args == "fail" {
+		return nil, errors.New("failed")
+	}
+	return nil, nil
+}
+
+func TestJobControl(t *testing.T) {
+	j := &echoJob{inputs: make(chan any, 1)}
+	jm := newTestManager(t, "test_job_control", j)
+	handlers := jm.Handlers()
+	mux := http.NewServeMux()
+	for _, h := range handlers {
+		mux.Handle(h.Path, h.Handler)
+	}
+	do := func(method, path, body string) (int, JobStatus) {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+		var status JobStatus
+		_ = json.Unmarshal(rec.Body.Bytes(), &status)
+		return rec.Code, status
+	}
+
+	// Paused jobs block the submission of executions, until resumed.
+	code, status := do(http.MethodPost, "/jobs/echo/pause", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, JobStatusPaused, status.Status)
+	go jm.executorFor(j).Submit(func() { _, _ = j.Execute(context.Background(), "produced") })
+	select {
+	case <-j.inputs:
+		t.Fatal("paused job executed")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Triggered executions run even when paused, and are recorded.
+	code, _ = do(http.MethodPost, "/jobs/echo/trigger", `"fail"`)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "fail", <-j.inputs)
+	require.Eventually(t, func() bool {
+		_, status = do(http.MethodGet, "/jobs/echo", "")
+		return status.Runs == 1
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, uint64(1), status.Failures)
+	require.Equal(t, "failed", status.LastError)
+	require.NotNil(t, status.LastRun)
+
+	code, _ = do(http.MethodPost, "/jobs/echo/resume", "")
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, "produced", <-j.inputs)
+
+	code, _ = do(http.MethodPost, "/jobs/unknown/pause", "")
+	require.Equal(t, http.StatusNotFound, code)
+	require.Len(t, jm.Jobs(), 1)
+	require.Equal(t, "basic", jm.Jobs()[0].Type)
+}
+
+func TestStoppedJobStaysPaused(t *testing.T) {
+	jm := newTestManager(t, "test_stop_paused")
+	jm.runCtx = jm.ctx
+
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+	require.Eventually(
+		t, func() bool { return j.executions.Load() > 0 }, time.Second, time.Millisecond,
+	)
+
+	// Stopping the producer of a paused job unblocks it, but does not resume the job.
+	require.NoError(t, jm.Pause(j.RegistryKey()))
+	require.NoError(t, jm.stopProducer(context.Background(), j.RegistryKey()))
+	s, err := jm.state(j.RegistryKey())
+	require.NoError(t, err)
+	s.mu.Lock()
+	paused := s.resumed != nil
+	s.mu.Unlock()
+	require.True(t, paused)
+
+	// Once restarted, the producer stays blocked until the job is resumed.
+	jm.startProducer(jm.runCtx, j, func(producer func()) { go producer() })
+	executions := j.executions.Load()
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, executions, j.executions.Load())
+
+	require.NoError(t, jm.Resume(j.RegistryKey()))
+	require.Eventually(
+		t, func() bool { return j.executions.Load() > executions }, time.Second, time.Millisecond,
+	)
+	require.NoError(t, jm.RemoveJob(context.Background(), j.RegistryKey()))
+}
@@ -0,0 +1,122 @@
+package baseapp
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/berachain/offchain-sdk/server"
+)
+
+// jobsPath is the path of the job control HTTP handlers.
+const jobsPath = "/jobs"
+
+// maxTriggerInputSize bounds the size of the JSON input of a triggered execution.
+const maxTriggerInputSize = 1 << 20
+
+// Handlers returns the job control HTTP handlers:
+//
+//	GET  /jobs                list the jobs with their status
+//	GET  /jobs/{key}          get the status of a job
+//	POST /jobs/{key}/pause    pause a job
+//	POST /jobs/{key}/resume   resume a job
+//	POST /jobs/{key}/trigger  trigger an execution of a job, with the (optional) JSON body as input
+//
+// The handlers are not authenticated; use a middleware to restrict access if needed.
+func (jm *JobManager) Handlers() []*server.Handler {
+	return []*server.Handler{
+		{Path: jobsPath, Handler: http.HandlerFunc(jm.handleList)},
+		{Path: jobsPath + "/", Handler: http.HandlerFunc(jm.handleJob)},
+	}
+}
+
+// handleList lists the jobs with their status.
+func (jm *JobManager) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	writeJSON(w, http.StatusOK, jm.Jobs())
+}
+
+// handleJob handles the requests for a single job.
+func (jm *JobManager) handleJob(w http.ResponseWriter, r *http.Request) {
+	key, action, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, jobsPath+"/"), "/")
+	if key == "" {
+		jm.handleList(w, r)
+		return
+	}
+
+	if action == "" {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		status, err := jm.Job(key)
+		if err != nil {
+			writeError(w, http.StatusNotFound, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, status)
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	var err error
+	switch action {
+	case "pause":
+		err = jm.Pause(key)
+	case "resume":
+		err = jm.Resume(key)
+	case "trigger":
+		var input any
+		if input, err = readInput(r); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		err = jm.Trigger(key, input)
+	default:
+		writeError(w, http.StatusNotFound, errors.New("unknown action "+action))
+		return
+	}
+
+	switch {
+	case errors.Is(err, ErrJobNotFound):
+		writeError(w, http.StatusNotFound, err)
+	case err != nil:
+		writeError(w, http.StatusConflict, err)
+	default:
+		status, _ := jm.Job(key)
+		writeJSON(w, http.StatusOK, status)
+	}
+}
+
+// readInput reads the (optional) JSON input of a triggered execution from the request body.
+func readInput(r *http.Request) (any, error) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxTriggerInputSize))
+	if err != nil || len(body) == 0 {
+		return nil, err
+	}
+	var input any
+	if err = json.Unmarshal(body, &input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// writeJSON writes the given value as a JSON response.
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes the given error as a JSON response.
+func writeError(w http.ResponseWriter, code int, err error) {
+	writeJSON(w, code, map[string]string{"error": err.Error()})
+}
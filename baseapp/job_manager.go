@@ -7,14 +7,20 @@ import (
 	"fmt"
 	"math/big"
 	"reflect"
+	"slices"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/berachain/offchain-sdk/config"
 	"github.com/berachain/offchain-sdk/job"
-	workertypes "github.com/berachain/offchain-sdk/job/types"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry/health"
+	"github.com/berachain/offchain-sdk/tools/leader"
 	sdk "github.com/berachain/offchain-sdk/types"
 	"github.com/berachain/offchain-sdk/worker"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 const (
@@ -49,16 +55,73 @@ type JobManager struct {
 	// are fed jobs by the job producers.
 	executorCfg  *worker.PoolConfig
 	jobExecutors *worker.Pool
+
+	// ctx is the context the manager was started with, which cancels the workers on shutdown.
+	ctx context.Context
+
+	// executionCfgs are the execution configs of jobs, by registry key, from the app config file.
+	executionCfgs map[string]job.ExecutionConfig
+
+	// workersCfg is the config of the worker pools, from the app config file.
+	workersCfg worker.Config
+
+	// executors are the executors of jobs, by registry key, which submit their executions to the
+	// shared executor pool or a dedicated pool (see `executor.go`).
+	executorsMu    sync.Mutex
+	executors      map[string]*jobExecutor
+	dedicatedPools map[string]*worker.Pool
+
+	// registryMu guards the job registry (and consumers), which jobs can be added to and removed
+	// from at runtime. runCtx is the context the producers are run with, once they are run, which
+	// is cancelled by cancelProducers on shutdown.
+	registryMu      sync.Mutex
+	runCtx          context.Context
+	cancelProducers context.CancelFunc
+
+	// setupOrder is the order the jobs (and consumers) were set up in, to tear them down in
+	// reverse order.
+	setupOrder []job.Basic
+
+	// shutdownCfg configures the shutdown sequence (see `shutdown.go`). submitCtx is done when
+	// the shutdown starts, after which executions are no longer submitted (and counted in
+	// rejected), and cancel cancels ctx once the shutdown is done.
+	shutdownCfg config.ShutdownConfig
+	submitCtx   context.Context
+	stopSubmit  context.CancelFunc
+	rejected    atomic.Int64
+	cancel      context.CancelFunc
+
+	// health (optional) is the registry of health checks, to which the checks of the job manager
+	// and of the jobs that check their own health are registered (see `health.go`).
+	health *health.Registry
+
+	// elector (optional) elects the replica that runs the producers of singleton jobs.
+	elector *leader.Elector
+
+	// states are the runtime states of jobs, by registry key (see `job_control.go`).
+	statesMu sync.RWMutex
+	states   map[string]*jobState
+
+	// consumers are the downstream consumers of the registered jobs that are not registered
+	// themselves, which are set up and torn down alongside them.
+	consumers []job.Basic
 }
 
 // NewManager creates a new manager.
 func NewManager(
 	jobs []job.Basic,
+	executionCfgs map[string]job.ExecutionConfig,
+	workersCfg worker.Config,
 	ctxFactory *contextFactory,
 ) *JobManager {
 	m := &JobManager{
-		jobRegistry: job.NewRegistry(),
-		ctxFactory:  ctxFactory,
+		jobRegistry:    job.NewRegistry(),
+		ctxFactory:     ctxFactory,
+		executionCfgs:  executionCfgs,
+		workersCfg:     workersCfg,
+		executors:      make(map[string]*jobExecutor),
+		dedicatedPools: make(map[string]*worker.Pool),
+		states:         make(map[string]*jobState),
 	}
 
 	// Register all supplied jobs with the manager.
@@ -66,25 +129,28 @@ func NewManager(
 		if err := m.jobRegistry.Register(j); err != nil {
 			panic(err)
 		}
+		m.addState(j, jobType(j))
 	}
 
-	// TODO: read pool configs from the config file.
-
-	// Setup the producer worker pool.
+	// Setup the producer worker pool, from the config file (if set). Each producer occupies a
+	// worker, so there must be at least as many workers (and queue slots) as jobs.
 	jobCount := uint16(m.jobRegistry.Count())
-	m.producerCfg = &worker.PoolConfig{
+	m.producerCfg = workersCfg.Producer.WithDefaults(&worker.PoolConfig{
 		Name:             producerName,
 		PrometheusPrefix: producerPromName,
 		MinWorkers:       jobCount,
 		MaxWorkers:       jobCount + 1,
 		ResizingStrategy: producerResizeStrategy,
 		MaxQueuedJobs:    jobCount,
-	}
+	})
+	m.producerCfg.MaxWorkers = max(m.producerCfg.MaxWorkers, jobCount)
+	m.producerCfg.MaxQueuedJobs = max(m.producerCfg.MaxQueuedJobs, jobCount)
 
-	// Setup the executor worker pool.
-	m.executorCfg = worker.DefaultPoolConfig()
-	m.executorCfg.Name = executorName
-	m.executorCfg.PrometheusPrefix = executorPromName
+	// Setup the executor worker pool, from the config file (if set).
+	executorDefaults := worker.DefaultPoolConfig()
+	executorDefaults.Name = executorName
+	executorDefaults.PrometheusPrefix = executorPromName
+	m.executorCfg = workersCfg.Executor.WithDefaults(executorDefaults)
 
 	// Return the manager.
 	return m
@@ -95,163 +161,198 @@ func (jm *JobManager) Logger(ctx context.Context) log.Logger {
 	return sdk.UnwrapContext(ctx).Logger().With("namespace", "job-manager")
 }
 
-// Start calls `Setup` on the jobs in the registry as well as spins up
-// the worker pools.
+// Start spins up the worker pools. The manager runs until Stop is called (rather than until the
+// given context is done), so that in-flight executions can be drained on shutdown.
 func (jm *JobManager) Start(ctx context.Context) {
 	// We pass in the context in order to handle cancelling the workers. We pass the
 	// standard go context and not an sdk.Context here since the context here is just used
 	// for cancelling the workers on shutdown.
 	logger := jm.ctxFactory.logger
-	jm.jobExecutors = worker.NewPool(ctx, logger, jm.executorCfg)
-	jm.jobProducers = worker.NewPool(ctx, logger, jm.producerCfg)
+	jm.ctx, jm.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	jm.submitCtx, jm.stopSubmit = context.WithCancel(jm.ctx)
+	jm.jobExecutors = worker.NewPool(jm.ctx, logger, jm.executorCfg)
+	jm.jobProducers = worker.NewPool(jm.ctx, logger, jm.producerCfg)
 }
 
-// Stop calls `Teardown` on the jobs in the registry as well as
-// shut's down all the worker pools.
-func (jm *JobManager) Stop() {
-	var wg sync.WaitGroup
-
-	// Shutdown producers.
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		jm.jobProducers.Stop()
-		jm.jobProducers = nil
-	}()
-
-	// Shutdown executors and call Teardown().
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		jm.jobExecutors.StopAndWait()
-		for _, j := range jm.jobRegistry.Iterate() {
-			if tj, ok := j.(job.HasTeardown); ok {
-				if err := tj.Teardown(); err != nil {
-					panic(err)
-				}
+// RunProducers sets up each job and runs its producer, until the producers are stopped by Stop.
+func (jm *JobManager) RunProducers(gctx context.Context) {
+	jm.registryMu.Lock()
+	defer jm.registryMu.Unlock()
+	jm.runCtx, jm.cancelProducers = context.WithCancel(context.WithoutCancel(gctx))
+	gctx = jm.runCtx
+
+	// Load all jobs in registry in the order they were registered.
+	orderedJobs, err := jm.jobRegistry.IterateInOrder()
+	if err != nil {
+		panic(err)
+	}
+
+	for _, jobID := range orderedJobs.Keys() {
+		if err = jm.runJob(gctx, jm.jobRegistry.Get(jobID), jm.jobProducers.Submit); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// runJob sets up the job and submits its producer with the given submit function, unless it is
+// a singleton job and this replica is not the leader (see `leader.go`). Must be called with
+// registryMu held.
+func (jm *JobManager) runJob(gctx context.Context, j job.Basic, submit func(func())) error {
+	if jobType(j) == "basic" {
+		return fmt.Errorf("unknown job type %s", reflect.TypeOf(j))
+	}
+
+	ctx := jm.ctxFactory.NewSDKContext(gctx)
+	if sj, ok := j.(job.HasSetup); ok {
+		if err := sj.Setup(ctx); err != nil {
+			return err
+		}
+	}
+	jm.setupOrder = append(jm.setupOrder, j)
+	if err := jm.setupConsumers(ctx, j); err != nil {
+		// Tear down the job, as it will not run.
+		if tj, ok := j.(job.HasTeardown); ok {
+			if tErr := tj.Teardown(); tErr != nil {
+				err = errors.Join(err, fmt.Errorf("tearing down job %s: %w", j.RegistryKey(), tErr))
 			}
 		}
-		jm.jobExecutors = nil
-	}()
+		return err
+	}
 
-	// Wait for both to finish. (Should we add a timeout?)
-	wg.Wait()
+	if jm.isStandby(j) {
+		if s, err := jm.state(j.RegistryKey()); err == nil {
+			s.setStandby()
+		}
+		return nil
+	}
+	jm.startProducer(gctx, j, submit)
+	return nil
 }
 
-func (jm *JobManager) runProducer(ctx context.Context, j job.Basic) bool {
-	// Handle migrated jobs.
-	if wrappedJob := job.WrapJob(j); wrappedJob != nil {
-		jm.jobProducers.Submit(
-			func() {
-				if err := wrappedJob.Producer(
-					ctx, jm.jobExecutors,
-				); !errors.Is(err, context.Canceled) && err != nil {
-					jm.Logger(ctx).Error("error in job producer", "err", err)
-				}
-			},
-		)
-		return true
+// startProducer submits the producer of the job with the given submit function, unless it is
+// already running. The producer runs with a context that is cancelled when it is stopped (see
+// stopProducer).
+func (jm *JobManager) startProducer(gctx context.Context, j job.Basic, submit func(func())) {
+	s, err := jm.state(j.RegistryKey())
+	if err != nil || s.running() {
+		return
 	}
-	return false
+
+	jctx, cancel := context.WithCancel(gctx)
+	producer := jm.producer(jm.ctxFactory.NewSDKContext(jctx), j)
+	done := make(chan struct{})
+	s.setRunning(cancel, done)
+	submit(func() {
+		defer close(done)
+		defer cancel()
+		defer jm.setStopped(j.RegistryKey())
+		producer()
+	})
 }
 
-// RunProducers sets up each job and runs its producer.
-func (jm *JobManager) RunProducers(gctx context.Context) { //nolint:gocognit // todo fix.
-	// Load all jobs in registry in the order they were registered.
-	orderedJobs, err := jm.jobRegistry.IterateInOrder()
+// stopProducer stops the producer of the job with the given registry key (unblocking it if the
+// job is paused, which it stays) and waits for it to return, or for the context to be done.
+func (jm *JobManager) stopProducer(ctx context.Context, key string) error {
+	s, err := jm.state(key)
 	if err != nil {
-		panic(err)
+		return err
+	}
+	done := s.stop()
+	if done == nil {
+		return nil
 	}
 
-	for _, jobID := range orderedJobs.Keys() {
-		j := jm.jobRegistry.Get(jobID)
-		ctx := jm.ctxFactory.NewSDKContext(gctx)
-		if sj, ok := j.(job.HasSetup); ok {
-			if err = sj.Setup(ctx); err != nil {
-				panic(err)
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// producer returns the producer of the given job, or nil if the job type is unknown.
+func (jm *JobManager) producer(ctx *sdk.Context, j job.Basic) func() {
+	executor := jm.executorFor(j)
+
+	// Handle migrated jobs.
+	if wrappedJob := job.WrapJob(j); wrappedJob != nil {
+		return func() {
+			if err := wrappedJob.Producer(
+				ctx, executor,
+			); !errors.Is(err, context.Canceled) && err != nil {
+				jm.Logger(ctx).Error("error in job producer", "err", err)
 			}
 		}
+	}
 
-		if jm.runProducer(ctx, j) { //nolint:nestif // todo fix.
-			continue
-		} else if subJob, ok := j.(job.Subscribable); ok {
-			jm.jobProducers.Submit(func() {
-				ch := subJob.Subscribe(ctx)
-				for {
-					select {
-					case val := <-ch:
-						jm.jobExecutors.Submit(workertypes.NewPayload(ctx, subJob, val).Execute)
-					case <-ctx.Done():
-						return
-					default:
-						continue
-					}
-				}
-			})
-		} else if ethSubJob, ok := j.(job.EthSubscribable); ok { //nolint:govet // todo fix.
-			jm.jobProducers.Submit(withRetry(func() bool {
-				//nolint:govet // todo fix.
-				sub, ch, err := ethSubJob.Subscribe(ctx)
+	// Subscription jobs resubscribe (with backoff) when their subscription fails or is closed.
+	switch subJob := j.(type) {
+	case job.Subscribable:
+		return subscription(ctx, jm, subJob, func() (<-chan any, <-chan error, func(), error) {
+			if ch := subJob.Subscribe(ctx); ch != nil {
+				return ch, nil, func() {}, nil
+			}
+			return nil, nil, nil, errors.New("nil subscription channel")
+		})
+	// Logs and block headers are tracked across resubscriptions to handle reorgs (see `reorg.go`).
+	case job.EthSubscribable:
+		tracker := newLogTracker(ctx.Chain(), subJob)
+		return subscription(ctx, jm, reorgJob{subJob},
+			func() (<-chan any, <-chan error, func(), error) {
+				sub, ch, err := subJob.Subscribe(ctx)
 				if err != nil {
-					jm.Logger(ctx).Error("error subscribing block header", "err", err)
-					return true
+					return nil, nil, nil, err
 				}
-				jm.Logger(ctx).Info("(re)subscribed to eth subscription", "job", j.RegistryKey())
-
-				for {
-					select {
-					case <-ctx.Done():
-						ethSubJob.Unsubscribe(ctx)
-						return false
-					case err = <-sub.Err():
-						jm.Logger(ctx).Error("error in subscription", "err", err)
-						ethSubJob.Unsubscribe(ctx)
-						// retry
-						return true
-					case val := <-ch:
-						jm.jobExecutors.Submit(workertypes.NewPayload(ctx, ethSubJob, val).Execute)
-						continue
-					}
+				var interval time.Duration
+				if tracker.cfg.FinalityDepth > 0 {
+					interval = tracker.cfg.PollInterval
 				}
-			}, jm.Logger(ctx)))
-		} else if blockHeaderJob, ok := j.(job.BlockHeaderSub); ok { //nolint:govet // todo fix.
-			//nolint:govet // todo fix.
-			jm.jobProducers.Submit(withRetry(func() bool {
-				sub, ch, err := blockHeaderJob.Subscribe(ctx)
+				vals, errs, stop := trackReorgs[coretypes.Log](ctx, ch, sub.Err(), tracker, interval)
+				return vals, errs, func() { subJob.Unsubscribe(ctx); stop() }, nil
+			})
+	case job.BlockHeaderSub:
+		tracker := newHeaderTracker(ctx.Chain(), subJob)
+		return subscription(ctx, jm, reorgJob{subJob},
+			func() (<-chan any, <-chan error, func(), error) {
+				sub, ch, err := subJob.Subscribe(ctx)
 				if err != nil {
-					jm.Logger(ctx).Error("error subscribing block header", "err", err)
-					return true
-				}
-				jm.Logger(ctx).Info("(re)subscribed to block header sub", "job", j.RegistryKey())
-
-				for {
-					select {
-					case <-ctx.Done():
-						blockHeaderJob.Unsubscribe(ctx)
-						return false
-					case err = <-sub.Err():
-						jm.Logger(ctx).Error("error in subscription", "err", err)
-						blockHeaderJob.Unsubscribe(ctx)
-						return true
-					case val := <-ch:
-						jm.jobExecutors.Submit(workertypes.NewPayload(ctx, blockHeaderJob, val).Execute)
-						continue
-					}
+					return nil, nil, nil, err
 				}
-			}, jm.Logger(ctx)))
-		} else {
-			panic(fmt.Sprintf("unknown job type %s", reflect.TypeOf(j)))
+				vals, errs, stop := trackReorgs[*coretypes.Header](ctx, ch, sub.Err(), tracker, 0)
+				return vals, errs, func() { subJob.Unsubscribe(ctx); stop() }, nil
+			})
+	}
+	return nil
+}
+
+// setupConsumers sets up the downstream consumers of the given job that are not registered (and
+// thus set up) themselves. Must be called with registryMu held.
+func (jm *JobManager) setupConsumers(ctx context.Context, j job.Basic) error {
+	for _, c := range job.ConsumersOf(j) {
+		if jm.jobRegistry.Get(c.RegistryKey()) != nil || slices.ContainsFunc(
+			jm.consumers, func(s job.Basic) bool { return s.RegistryKey() == c.RegistryKey() },
+		) {
+			continue
+		}
+		if sc, ok := c.(job.HasSetup); ok {
+			if err := sc.Setup(ctx); err != nil {
+				return err
+			}
 		}
+		jm.consumers = append(jm.consumers, c)
+		jm.setupOrder = append(jm.setupOrder, c)
+		jm.addState(c, "consumer")
 	}
+	return nil
 }
 
-// withRetry is a wrapper that retries a task with exponential backoff.
-func withRetry(task func() bool, logger log.Logger) func() {
+// withRetry is a wrapper that retries a task with exponential backoff, until the context is done.
+func withRetry(ctx context.Context, task func() bool, logger log.Logger) func() {
 	return func() {
 		backoff := backoffStart
 
 		for {
-			if retry := task(); retry {
+			if retry := task(); retry && ctx.Err() == nil {
 				// Exponential backoff with jitter.
 				jitter, _ := rand.Int(rand.Reader, big.NewInt(jitterRange))
 				if jitter == nil {
@@ -259,7 +360,11 @@ func withRetry(task func() bool, logger log.Logger) func() {
 				}
 				sleep := backoff + time.Duration(jitter.Int64())*time.Millisecond
 				logger.Info(fmt.Sprintf("retrying task in %s...", sleep))
-				time.Sleep(sleep)
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(sleep):
+				}
 				backoff *= backoffBase
 				if backoff > maxBackoff {
 					backoff = maxBackoff
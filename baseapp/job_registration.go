@@ -0,0 +1,101 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/berachain/offchain-sdk/job"
+)
+
+// ErrJobExists is returned when adding a job with the registry key of a registered job.
+var ErrJobExists = errors.New("job already registered")
+
+// AddJob registers the given job at runtime. If the producers are already running, the job is set
+// up and its producer is run (in its own goroutine, as the producer pool is sized for the jobs
+// registered when building the app); otherwise it is run along with the other jobs. Safe to call
+// concurrently.
+func (jm *JobManager) AddJob(j job.Basic) error {
+	jm.registryMu.Lock()
+	defer jm.registryMu.Unlock()
+
+	key := j.RegistryKey()
+	if jm.jobRegistry.Has(key) {
+		return fmt.Errorf("%w: %s", ErrJobExists, key)
+	}
+	if err := jm.jobRegistry.Register(j); err != nil {
+		return err
+	}
+	jm.addState(j, jobType(j))
+	jm.registerHealth(j)
+
+	if jm.runCtx == nil {
+		return nil
+	}
+	if err := jm.runJob(jm.runCtx, j, func(producer func()) { go producer() }); err != nil {
+		jm.jobRegistry.Remove(key)
+		jm.setupOrder = slices.DeleteFunc(jm.setupOrder, func(s job.Basic) bool {
+			return s.RegistryKey() == key
+		})
+		jm.removeState(key)
+		jm.unregisterHealth(key)
+		jm.removeExecutor(key)
+		return fmt.Errorf("running job %s: %w", key, err)
+	}
+	jm.Logger(jm.ctxFactory.NewSDKContext(jm.runCtx)).Info("added job", "job", key)
+	return nil
+}
+
+// RemoveJob removes the job with the given registry key at runtime: its producer is stopped (which
+// unsubscribes subscriptions), its submitted executions are waited for and it is torn down. The
+// downstream consumers it set up are kept, as they may be shared. The job is only unregistered once
+// its producer has stopped, so that the removal can be retried if stopping it fails; meanwhile,
+// leader changes do not restart it. Safe to call concurrently.
+func (jm *JobManager) RemoveJob(ctx context.Context, key string) error {
+	jm.registryMu.Lock()
+	j := jm.jobRegistry.Get(key)
+	s, err := jm.state(key)
+	if j == nil || err != nil {
+		jm.registryMu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobNotFound, key)
+	}
+	s.removing = true
+	jm.registryMu.Unlock()
+
+	// Stop the producer and wait for it to return.
+	if err = jm.stopProducer(ctx, key); err != nil {
+		jm.registryMu.Lock()
+		s.removing = false
+		jm.registryMu.Unlock()
+		return fmt.Errorf("stopping job %s: %w", key, err)
+	}
+
+	// Unregister the job, unless it was removed concurrently.
+	jm.registryMu.Lock()
+	if cur, _ := jm.state(key); cur != s {
+		jm.registryMu.Unlock()
+		return fmt.Errorf("%w: %s", ErrJobNotFound, key)
+	}
+	jm.jobRegistry.Remove(key)
+	jm.setupOrder = slices.DeleteFunc(jm.setupOrder, func(sj job.Basic) bool {
+		return sj.RegistryKey() == key
+	})
+	jm.removeState(key)
+	jm.registryMu.Unlock()
+	jm.unregisterHealth(key)
+
+	// Wait for the submitted executions, then tear down the job.
+	if e := jm.removeExecutor(key); e != nil {
+		e.wait(ctx)
+	}
+	if tj, ok := j.(job.HasTeardown); ok {
+		if err := tj.Teardown(); err != nil {
+			return fmt.Errorf("tearing down job %s: %w", key, err)
+		}
+	}
+	if jm.runCtx != nil {
+		jm.Logger(jm.ctxFactory.NewSDKContext(jm.runCtx)).Info("removed job", "job", key)
+	}
+	return nil
+}
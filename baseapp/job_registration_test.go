@@ -0,0 +1,118 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	workertypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/stretchr/testify/require"
+)
+
+// pollingJob is a polling job that counts its executions.
+type pollingJob struct {
+	executions      atomic.Int32
+	setUp, tornDown atomic.Bool
+}
+
+func (*pollingJob) RegistryKey() string { return "polling" }
+
+func (*pollingJob) IntervalTime(context.Context) time.Duration { return time.Millisecond }
+
+func (j *pollingJob) Setup(context.Context) error {
+	j.setUp.Store(true)
+	return nil
+}
+
+func (j *pollingJob) Teardown() error {
+	j.tornDown.Store(true)
+	return nil
+}
+
+func (j *pollingJob) Execute(context.Context, any) (any, error) {
+	j.executions.Add(1)
+	return nil, nil
+}
+
+func TestAddRemoveJob(t *testing.T) {
+	jm := newTestManager(t, "test_registration")
+	jm.runCtx = jm.ctx
+
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+	require.ErrorIs(t, jm.AddJob(j), ErrJobExists)
+	require.True(t, j.setUp.Load())
+	require.Eventually(
+		t, func() bool { return j.executions.Load() > 2 }, time.Second, time.Millisecond,
+	)
+
+	// Paused jobs are removed too.
+	require.NoError(t, jm.Pause(j.RegistryKey()))
+	require.NoError(t, jm.RemoveJob(context.Background(), j.RegistryKey()))
+	require.True(t, j.tornDown.Load())
+	executions := j.executions.Load()
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, executions, j.executions.Load())
+
+	require.ErrorIs(t, jm.RemoveJob(context.Background(), j.RegistryKey()), ErrJobNotFound)
+	require.Empty(t, jm.Jobs())
+}
+
+func TestRemoveJobKeepsJobIfProducerDoesNotStop(t *testing.T) {
+	jm := newTestManager(t, "test_removal")
+	jm.runCtx = jm.ctx
+
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+
+	// Simulate a producer that does not return in time.
+	s, err := jm.state(j.RegistryKey())
+	require.NoError(t, err)
+	s.mu.Lock()
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, jm.RemoveJob(ctx, j.RegistryKey()), context.DeadlineExceeded)
+	require.Len(t, jm.Jobs(), 1)
+	require.False(t, j.tornDown.Load())
+
+	// Once the producer stops, the removal can be retried.
+	s.mu.Lock()
+	close(s.done)
+	s.mu.Unlock()
+	require.NoError(t, jm.RemoveJob(context.Background(), j.RegistryKey()))
+	require.True(t, j.tornDown.Load())
+	require.Empty(t, jm.Jobs())
+}
+
+// brokenConsumer is a consumer job that fails to set up.
+type brokenConsumer struct{}
+
+func (brokenConsumer) RegistryKey() string                       { return "broken" }
+func (brokenConsumer) Execute(context.Context, any) (any, error) { return nil, nil }
+func (brokenConsumer) Setup(context.Context) error               { return errors.New("broken") }
+
+// pipedJob is a polling job with a consumer.
+type pipedJob struct {
+	*pollingJob
+}
+
+func (pipedJob) Consumers() []workertypes.Executable {
+	return []workertypes.Executable{brokenConsumer{}}
+}
+
+func TestAddJobTearsDownOnFailure(t *testing.T) {
+	jm := newTestManager(t, "test_add_failure")
+	jm.runCtx = jm.ctx
+
+	// The job is torn down if it fails to run after being set up.
+	j := pipedJob{&pollingJob{}}
+	require.Error(t, jm.AddJob(j))
+	require.True(t, j.setUp.Load())
+	require.True(t, j.tornDown.Load())
+	require.Empty(t, jm.Jobs())
+}
@@ -0,0 +1,77 @@
+package baseapp
+
+import (
+	"context"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/tools/leader"
+)
+
+// setElector sets the elector of the replica that runs the producers of singleton jobs.
+func (jm *JobManager) setElector(elector *leader.Elector) {
+	jm.elector = elector
+	elector.OnChange(jm.onLeaderChange)
+}
+
+// isStandby returns whether the given job is a singleton job that must not run its producer, as
+// this replica is not the leader.
+func (jm *JobManager) isStandby(j job.Basic) bool {
+	return jm.elector != nil && !jm.elector.IsLeader() &&
+		jm.executionConfig(j, j.RegistryKey()).Singleton
+}
+
+// onLeaderChange starts the producers of the singleton jobs when this replica is elected leader,
+// and stops them (waiting for their submitted executions) when it steps down. Stopping is waited
+// for outside the registry lock, for at most a lease duration.
+func (jm *JobManager) onLeaderChange(isLeader bool) {
+	jm.registryMu.Lock()
+	if jm.runCtx == nil {
+		jm.registryMu.Unlock()
+		return
+	}
+	orderedJobs, err := jm.jobRegistry.IterateInOrder()
+	if err != nil {
+		jm.registryMu.Unlock()
+		return
+	}
+	logger := jm.Logger(jm.ctxFactory.NewSDKContext(jm.runCtx))
+	var (
+		singletons []job.Basic
+		executors  []*jobExecutor
+	)
+	for _, key := range orderedJobs.Keys() {
+		j := jm.jobRegistry.Get(key)
+		if !jm.executionConfig(j, key).Singleton {
+			continue
+		}
+		// Jobs being removed are stopped by the removal.
+		if s, stateErr := jm.state(key); stateErr != nil || s.removing {
+			continue
+		}
+		if isLeader {
+			logger.Info("starting singleton job", "job", key)
+			jm.startProducer(jm.runCtx, j, func(producer func()) { go producer() })
+			continue
+		}
+		singletons = append(singletons, j)
+		executors = append(executors, jm.executorFor(j))
+	}
+	jm.registryMu.Unlock()
+
+	// The run context may be done already, if stepping down on shutdown.
+	ctx, cancel := context.WithTimeout(context.Background(), jm.elector.LeaseDuration())
+	defer cancel()
+	for i, j := range singletons {
+		key := j.RegistryKey()
+		logger.Info("stopping singleton job", "job", key)
+		// Mark the job as standby first, so that its stopping producer is not reported as stopped.
+		if s, stateErr := jm.state(key); stateErr == nil {
+			s.setStandby()
+		}
+		if err = jm.stopProducer(ctx, key); err != nil {
+			logger.Error("error stopping singleton job", "job", key, "err", err)
+			continue
+		}
+		executors[i].wait(ctx)
+	}
+}
@@ -0,0 +1,112 @@
+package baseapp
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/tools/leader"
+	"github.com/berachain/offchain-sdk/tools/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingletonJobs(t *testing.T) {
+	jm := newTestManager(t, "test_leader")
+	jm.runCtx = jm.ctx
+	jm.executionCfgs = map[string]job.ExecutionConfig{"polling": {Singleton: true}}
+	elector := leader.New(
+		store.NewInMemoryLocker(), leader.Config{LeaseDuration: 30 * time.Millisecond},
+		log.NewLogger(os.Stdout, "test-runner"),
+	)
+	jm.setElector(elector)
+
+	// Singleton jobs wait for the replica to be elected leader.
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+	status, err := jm.Job(j.RegistryKey())
+	require.NoError(t, err)
+	require.Equal(t, JobStatusStandby, status.Status)
+	time.Sleep(10 * time.Millisecond)
+	require.Zero(t, j.executions.Load())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx)
+	}()
+	require.Eventually(
+		t, func() bool { return j.executions.Load() > 2 }, time.Second, time.Millisecond,
+	)
+	status, _ = jm.Job(j.RegistryKey())
+	require.Equal(t, JobStatusRunning, status.Status)
+
+	// Stepping down stops the producer.
+	cancel()
+	<-done
+	status, _ = jm.Job(j.RegistryKey())
+	require.Equal(t, JobStatusStandby, status.Status)
+	executions := j.executions.Load()
+	time.Sleep(10 * time.Millisecond)
+	require.Equal(t, executions, j.executions.Load())
+}
+
+func TestSteppingDownKeepsProducersHealthy(t *testing.T) {
+	jm := newTestManager(t, "test_leader_health")
+	jm.runCtx = jm.ctx
+	jm.executionCfgs = map[string]job.ExecutionConfig{"polling": {Singleton: true}}
+	elector := leader.New(
+		store.NewInMemoryLocker(), leader.Config{LeaseDuration: 30 * time.Millisecond},
+		log.NewLogger(os.Stdout, "test-runner"),
+	)
+
+	// Add the job as if this replica was the leader.
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+	require.Eventually(
+		t, func() bool { return j.executions.Load() > 0 }, time.Second, time.Millisecond,
+	)
+	jm.elector = elector
+
+	// Stepped down singleton jobs are on standby, even while their producer is stopping, or if it
+	// does not stop in time.
+	s, err := jm.state(j.RegistryKey())
+	require.NoError(t, err)
+	s.mu.Lock()
+	done := s.done
+	s.done = make(chan struct{})
+	s.mu.Unlock()
+	jm.onLeaderChange(false)
+	<-done
+
+	status, err := jm.Job(j.RegistryKey())
+	require.NoError(t, err)
+	require.Equal(t, JobStatusStandby, status.Status)
+	require.NoError(t, jm.CheckProducers(context.Background()))
+}
+
+func TestLeaderChangeSkipsRemovingJobs(t *testing.T) {
+	jm := newTestManager(t, "test_leader_removing")
+	jm.runCtx = jm.ctx
+	jm.executionCfgs = map[string]job.ExecutionConfig{"polling": {Singleton: true}}
+
+	j := &pollingJob{}
+	require.NoError(t, jm.AddJob(j))
+	jm.elector = leader.New(
+		store.NewInMemoryLocker(), leader.Config{LeaseDuration: 30 * time.Millisecond},
+		log.NewLogger(os.Stdout, "test-runner"),
+	)
+
+	// A job whose producer was stopped for its removal is not restarted on election.
+	s, err := jm.state(j.RegistryKey())
+	require.NoError(t, err)
+	jm.registryMu.Lock()
+	s.removing = true
+	jm.registryMu.Unlock()
+	require.NoError(t, jm.stopProducer(context.Background(), j.RegistryKey()))
+	jm.onLeaderChange(true)
+	require.False(t, s.running())
+}
@@ -0,0 +1,405 @@
+package baseapp
+
+import (
+	"context"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
+	workertypes "github.com/berachain/offchain-sdk/job/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	defaultReorgHistory      = 64
+	defaultReorgPollInterval = 2 * time.Second
+)
+
+// reorgConfig returns the reorg config of the given job, with any unset fields set to their
+// defaults.
+func reorgConfig(j job.Basic) job.ReorgConfig {
+	var cfg job.ReorgConfig
+	if hrc, ok := workertypes.As[job.HasReorgConfig](j); ok {
+		cfg = hrc.ReorgConfig()
+	}
+	if cfg.History == 0 {
+		cfg.History = defaultReorgHistory
+	}
+	cfg.History = max(cfg.History, cfg.FinalityDepth)
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultReorgPollInterval
+	}
+	return cfg
+}
+
+// reorgJob executes the values of a reorg-aware subscription of the wrapped job: removed logs and
+// reverted blocks are passed to the job's revert handler, and all other values to Execute.
+type reorgJob struct {
+	job.Basic
+}
+
+// Execute implements job.Basic.
+func (r reorgJob) Execute(ctx context.Context, args any) (any, error) {
+	switch args.(type) {
+	case job.LogRemoved, job.BlockReverted:
+		if rh, ok := workertypes.As[job.HasRevertHandler](r.Basic); ok {
+			return nil, rh.Revert(ctx, args)
+		}
+		return nil, nil
+	}
+	return r.Basic.Execute(ctx, args)
+}
+
+// Unwrap implements workertypes.Unwrapper.
+func (r reorgJob) Unwrap() workertypes.Executable {
+	return r.Basic
+}
+
+// reorgTracker tracks the values of a subscription to detect reorgs, returning the values to
+// execute for each value received (and periodically, on tick).
+type reorgTracker[T any] interface {
+	receive(ctx context.Context, val T) ([]any, error)
+	tick(ctx context.Context) ([]any, error)
+}
+
+// trackReorgs returns the channels of values and errors of the given subscription channels, as
+// tracked by the given tracker, which is ticked at the given interval (if non-zero), and the
+// function to stop tracking. The returned error channel receives the errors of the subscription
+// and of the tracker.
+func trackReorgs[T any](
+	ctx context.Context, ch <-chan T, errs <-chan error, tracker reorgTracker[T],
+	interval time.Duration,
+) (<-chan any, <-chan error, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	out, outErrs := make(chan any), make(chan error, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(out)
+
+		var tick <-chan time.Time
+		if interval > 0 {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			var (
+				vals []any
+				err  error
+			)
+			select {
+			case <-ctx.Done():
+				return
+			case err = <-errs:
+				outErrs <- err
+				return
+			case val, ok := <-ch:
+				if !ok {
+					return
+				}
+				vals, err = tracker.receive(ctx, val)
+			case <-tick:
+				vals, err = tracker.tick(ctx)
+			}
+
+			for _, val := range vals {
+				select {
+				case <-ctx.Done():
+					return
+				case out <- val:
+				}
+			}
+			if err != nil && ctx.Err() == nil {
+				outErrs <- err
+				return
+			}
+		}
+	}()
+
+	// Wait for the tracker to stop, so that it is not shared with that of a resubscription.
+	return out, outErrs, func() {
+		cancel()
+		<-done
+	}
+}
+
+// ============================================
+// Logs
+// ============================================
+
+// trackedLogs are the logs received from a recent block.
+type trackedLogs struct {
+	hash common.Hash
+	logs []coretypes.Log
+	// final is true if the block is final, and its logs are executed.
+	final bool
+}
+
+// logTracker tracks the logs of the recent blocks of an event job, to detect reorgs (from removed
+// logs or from logs of a block with a different hash) and to hold logs back until they are final.
+type logTracker struct {
+	chain  eth.Client
+	cfg    job.ReorgConfig
+	revert bool
+
+	blocks map[uint64]*trackedLogs
+	head   uint64
+}
+
+// newLogTracker creates a new log tracker for the given event job.
+func newLogTracker(chain eth.Client, j job.Basic) *logTracker {
+	_, revert := workertypes.As[job.HasRevertHandler](j)
+	return &logTracker{
+		chain:  chain,
+		cfg:    reorgConfig(j),
+		revert: revert,
+		blocks: make(map[uint64]*trackedLogs),
+	}
+}
+
+// receive implements reorgTracker.
+func (t *logTracker) receive(_ context.Context, l coretypes.Log) ([]any, error) {
+	b := t.blocks[l.BlockNumber]
+	if l.Removed {
+		if b == nil || b.hash != l.BlockHash {
+			return nil, nil
+		}
+		i := slices.IndexFunc(b.logs, func(tl coretypes.Log) bool { return tl.Index == l.Index })
+		if i < 0 {
+			return nil, nil
+		}
+		b.logs = slices.Delete(b.logs, i, i+1)
+		if b.final && t.revert {
+			return []any{job.LogRemoved{Log: l}}, nil
+		}
+		return nil, nil
+	}
+
+	// Logs of a block with a different hash replace that block, and the blocks after it.
+	var vals []any
+	if b != nil && b.hash != l.BlockHash {
+		vals = t.revertFrom(l.BlockNumber)
+		b = nil
+	}
+	if b == nil {
+		b = &trackedLogs{hash: l.BlockHash, final: t.cfg.FinalityDepth == 0}
+		t.blocks[l.BlockNumber] = b
+	}
+	if slices.ContainsFunc(b.logs, func(tl coretypes.Log) bool { return tl.Index == l.Index }) {
+		return vals, nil
+	}
+	b.logs = append(b.logs, l)
+	if b.final {
+		vals = append(vals, l)
+	}
+	t.head = max(t.head, l.BlockNumber)
+	t.prune()
+	return vals, nil
+}
+
+// tick implements reorgTracker, executing the logs of the blocks that are final, if they are
+// still canonical (and dropping them otherwise).
+func (t *logTracker) tick(ctx context.Context) ([]any, error) {
+	pending := t.numbers(func(b *trackedLogs) bool { return !b.final })
+	if len(pending) == 0 {
+		return nil, nil
+	}
+	head, err := t.chain.BlockNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+	t.head = max(t.head, head)
+
+	var vals []any
+	for _, n := range pending {
+		if n+t.cfg.FinalityDepth > t.head {
+			break
+		}
+		header, err := t.chain.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+		if err != nil {
+			return vals, err
+		}
+		b := t.blocks[n]
+		if header.Hash() != b.hash {
+			delete(t.blocks, n)
+			continue
+		}
+		b.final = true
+		for _, l := range b.logs {
+			vals = append(vals, l)
+		}
+	}
+	t.prune()
+	return vals, nil
+}
+
+// revertFrom stops tracking the blocks from the given number, returning the events of their
+// executed logs that were removed, newest first.
+func (t *logTracker) revertFrom(number uint64) []any {
+	var vals []any
+	numbers := t.numbers(func(*trackedLogs) bool { return true })
+	for i := len(numbers) - 1; i >= 0 && numbers[i] >= number; i-- {
+		b := t.blocks[numbers[i]]
+		delete(t.blocks, numbers[i])
+		if !b.final || !t.revert {
+			continue
+		}
+		for j := len(b.logs) - 1; j >= 0; j-- {
+			l := b.logs[j]
+			l.Removed = true
+			vals = append(vals, job.LogRemoved{Log: l})
+		}
+	}
+	return vals
+}
+
+// prune stops tracking the final blocks older than the history. Blocks that are not final yet are
+// kept until tick finalizes (or drops) them, however old they are.
+func (t *logTracker) prune() {
+	for n, b := range t.blocks {
+		if b.final && n+t.cfg.History <= t.head {
+			delete(t.blocks, n)
+		}
+	}
+}
+
+// numbers returns the numbers of the tracked blocks that match the given filter, in order.
+func (t *logTracker) numbers(filter func(*trackedLogs) bool) []uint64 {
+	var numbers []uint64
+	for n, b := range t.blocks {
+		if filter(b) {
+			numbers = append(numbers, n)
+		}
+	}
+	slices.Sort(numbers)
+	return numbers
+}
+
+// ============================================
+// Block Headers
+// ============================================
+
+// trackedHeader is the header of a recent block.
+type trackedHeader struct {
+	header *coretypes.Header
+	// final is true if the block is final, and executed.
+	final bool
+}
+
+// headerTracker tracks the headers of the recent blocks of a block header job, to detect reorgs
+// (from headers that replace tracked blocks, or whose parent is not the tracked block) and to hold
+// blocks back until they are final.
+type headerTracker struct {
+	chain  eth.Client
+	cfg    job.ReorgConfig
+	revert bool
+
+	headers map[uint64]*trackedHeader
+	head    uint64
+}
+
+// newHeaderTracker creates a new header tracker for the given block header job.
+func newHeaderTracker(chain eth.Client, j job.Basic) *headerTracker {
+	_, revert := workertypes.As[job.HasRevertHandler](j)
+	return &headerTracker{
+		chain:   chain,
+		cfg:     reorgConfig(j),
+		revert:  revert,
+		headers: make(map[uint64]*trackedHeader),
+	}
+}
+
+// receive implements reorgTracker. On a reorg, the tracked blocks that are not ancestors of the
+// new head are reverted, newest first, and the new canonical blocks before the new head are
+// fetched and tracked, oldest first.
+func (t *headerTracker) receive(ctx context.Context, h *coretypes.Header) ([]any, error) {
+	number := h.Number.Uint64()
+	if th, ok := t.headers[number]; ok && th.header.Hash() == h.Hash() {
+		return nil, nil
+	}
+
+	vals := t.revertFrom(number)
+	var canonical []*coretypes.Header
+	for parent, n := h.ParentHash, number; n > 0; n-- {
+		th, ok := t.headers[n-1]
+		if !ok || th.header.Hash() == parent {
+			break
+		}
+		vals = append(vals, t.revertFrom(n-1)...)
+		header, err := t.chain.HeaderByNumber(ctx, new(big.Int).SetUint64(n-1))
+		if err != nil {
+			return vals, err
+		}
+		canonical = append(canonical, header)
+		parent = header.ParentHash
+	}
+
+	for i := len(canonical) - 1; i >= 0; i-- {
+		vals = append(vals, t.add(canonical[i])...)
+	}
+	vals = append(vals, t.add(h)...)
+	t.head = number
+
+	// Execute the blocks that are now final, in order.
+	var pending []uint64
+	for n, th := range t.headers {
+		if !th.final && n+t.cfg.FinalityDepth <= t.head {
+			pending = append(pending, n)
+		}
+	}
+	slices.Sort(pending)
+	for _, n := range pending {
+		t.headers[n].final = true
+		vals = append(vals, t.headers[n].header)
+	}
+
+	for n := range t.headers {
+		if n+t.cfg.History <= t.head {
+			delete(t.headers, n)
+		}
+	}
+	return vals, nil
+}
+
+// tick implements reorgTracker. Block headers are finalized as new heads are received.
+func (t *headerTracker) tick(context.Context) ([]any, error) {
+	return nil, nil
+}
+
+// add tracks the given header, returning it to be executed if blocks are executed as soon as they
+// are received.
+func (t *headerTracker) add(h *coretypes.Header) []any {
+	final := t.cfg.FinalityDepth == 0
+	t.headers[h.Number.Uint64()] = &trackedHeader{header: h, final: final}
+	if final {
+		return []any{h}
+	}
+	return nil
+}
+
+// revertFrom stops tracking the blocks from the given number, returning the events of the
+// executed blocks that were reverted, newest first.
+func (t *headerTracker) revertFrom(number uint64) []any {
+	var numbers []uint64
+	for n := range t.headers {
+		if n >= number {
+			numbers = append(numbers, n)
+		}
+	}
+	slices.Sort(numbers)
+
+	var vals []any
+	for i := len(numbers) - 1; i >= 0; i-- {
+		th := t.headers[numbers[i]]
+		delete(t.headers, numbers[i])
+		if th.final && t.revert {
+			vals = append(vals, job.BlockReverted{Header: th.header})
+		}
+	}
+	return vals
+}
@@ -0,0 +1,144 @@
+package baseapp
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// revertJob is an event and block header job that handles reverts.
+type revertJob struct {
+	cfg job.ReorgConfig
+}
+
+func (*revertJob) RegistryKey() string { return "revert" }
+
+func (*revertJob) Execute(context.Context, any) (any, error) { return nil, nil }
+
+func (j *revertJob) ReorgConfig() job.ReorgConfig { return j.cfg }
+
+func (*revertJob) Revert(context.Context, any) error { return nil }
+
+// headerChain serves the canonical headers of a fake chain.
+type headerChain struct {
+	eth.Client
+	headers map[uint64]*coretypes.Header
+}
+
+func (c *headerChain) BlockNumber(context.Context) (uint64, error) {
+	var head uint64
+	for n := range c.headers {
+		head = max(head, n)
+	}
+	return head, nil
+}
+
+func (c *headerChain) HeaderByNumber(_ context.Context, n *big.Int) (*coretypes.Header, error) {
+	return c.headers[n.Uint64()], nil
+}
+
+// fork returns the headers of a chain from the given parent, tagged with the given fork name.
+func fork(parent *coretypes.Header, name string, n int) []*coretypes.Header {
+	headers := make([]*coretypes.Header, n)
+	for i := range headers {
+		headers[i] = &coretypes.Header{
+			Number:     new(big.Int).Add(parent.Number, big.NewInt(1)),
+			ParentHash: parent.Hash(),
+			Extra:      []byte(name),
+		}
+		parent = headers[i]
+	}
+	return headers
+}
+
+func TestLogTrackerReorgs(t *testing.T) {
+	tracker := newLogTracker(nil, &revertJob{})
+	ctx := context.Background()
+	a := coretypes.Log{BlockNumber: 10, BlockHash: common.Hash{1}, Index: 0}
+	b := coretypes.Log{BlockNumber: 11, BlockHash: common.Hash{2}, Index: 1}
+
+	for _, l := range []coretypes.Log{a, b} {
+		vals, err := tracker.receive(ctx, l)
+		require.NoError(t, err)
+		require.Equal(t, []any{l}, vals)
+	}
+
+	// Duplicates are dropped.
+	vals, _ := tracker.receive(ctx, a)
+	require.Empty(t, vals)
+
+	// A log of block 10 with a different hash removes the logs of blocks 10 and 11.
+	replaced := coretypes.Log{BlockNumber: 10, BlockHash: common.Hash{3}, Index: 0}
+	vals, _ = tracker.receive(ctx, replaced)
+	removedA, removedB := a, b
+	removedA.Removed, removedB.Removed = true, true
+	require.Equal(t, []any{
+		job.LogRemoved{Log: removedB}, job.LogRemoved{Log: removedA}, replaced,
+	}, vals)
+
+	// Removed logs are passed to the revert handler, not executed.
+	replaced.Removed = true
+	vals, _ = tracker.receive(ctx, replaced)
+	require.Equal(t, []any{job.LogRemoved{Log: replaced}}, vals)
+}
+
+func TestLogTrackerFinalizesBursts(t *testing.T) {
+	genesis := &coretypes.Header{Number: big.NewInt(99)}
+	chain := &headerChain{headers: make(map[uint64]*coretypes.Header)}
+	for _, h := range fork(genesis, "canonical", 101) {
+		chain.headers[h.Number.Uint64()] = h
+	}
+
+	// A burst of logs far beyond the history (e.g. a backfill replay) must not drop the blocks
+	// that are not final yet.
+	tracker := newLogTracker(chain, &revertJob{cfg: job.ReorgConfig{FinalityDepth: 3, History: 3}})
+	ctx := context.Background()
+	for n := uint64(100); n <= 200; n++ {
+		vals, err := tracker.receive(ctx, coretypes.Log{
+			BlockNumber: n, BlockHash: chain.headers[n].Hash(),
+		})
+		require.NoError(t, err)
+		require.Empty(t, vals)
+	}
+
+	vals, err := tracker.tick(ctx)
+	require.NoError(t, err)
+	require.Len(t, vals, 98)
+	for i, val := range vals {
+		require.Equal(t, uint64(100+i), val.(coretypes.Log).BlockNumber)
+	}
+}
+
+func TestHeaderTrackerReorgsAndFinality(t *testing.T) {
+	genesis := &coretypes.Header{Number: big.NewInt(0)}
+	old := fork(genesis, "old", 3)
+	reorged := append([]*coretypes.Header{old[0]}, fork(old[0], "new", 3)...)
+	chain := &headerChain{headers: make(map[uint64]*coretypes.Header)}
+	for _, h := range reorged {
+		chain.headers[h.Number.Uint64()] = h
+	}
+
+	tracker := newHeaderTracker(chain, &revertJob{cfg: job.ReorgConfig{FinalityDepth: 1}})
+	ctx := context.Background()
+	var executed []any
+	for _, h := range old {
+		vals, err := tracker.receive(ctx, h)
+		require.NoError(t, err)
+		executed = append(executed, vals...)
+	}
+	// Blocks are executed once another block is built on top of them.
+	require.Equal(t, []any{old[0], old[1]}, executed)
+
+	// The new head replaces blocks 2 and 3: the executed block 2 is reverted (block 3 was not
+	// final, so it is dropped), and the new block 2 is fetched and executed.
+	vals, err := tracker.receive(ctx, reorged[3])
+	require.NoError(t, err)
+	require.Equal(t, []any{job.BlockReverted{Header: old[1]}, reorged[1], reorged[2]}, vals)
+}
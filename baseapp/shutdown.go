@@ -0,0 +1,159 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/berachain/offchain-sdk/config"
+	"github.com/berachain/offchain-sdk/job"
+)
+
+const (
+	defaultDrainTimeout    = 30 * time.Second
+	defaultProducerTimeout = 5 * time.Second
+)
+
+// shutdownConfig returns the given shutdown config, with any unset fields set to their defaults.
+func shutdownConfig(cfg config.ShutdownConfig) config.ShutdownConfig {
+	if cfg.DrainTimeout <= 0 {
+		cfg.DrainTimeout = defaultDrainTimeout
+	}
+	if cfg.ProducerTimeout <= 0 {
+		cfg.ProducerTimeout = defaultProducerTimeout
+	}
+	return cfg
+}
+
+// Stop shuts down the job manager, in order:
+//  1. stop submitting executions: producers are blocked and their executions rejected,
+//  2. drain the in-flight executions (and those of their downstream consumers), up to the drain
+//     timeout,
+//  3. cancel the producers, which unsubscribe from eth subscriptions, up to the producer timeout,
+//  4. stop the worker pools, cancelling the executions that were not drained,
+//  5. tear down the jobs (and consumers) in reverse setup order.
+//
+// What was abandoned (rejected executions, executions not drained, producers that did not return)
+// is logged; the returned error joins the teardown errors.
+func (jm *JobManager) Stop() error {
+	if jm.ctx == nil {
+		return nil
+	}
+	cfg := shutdownConfig(jm.shutdownCfg)
+	logger := jm.Logger(jm.ctxFactory.NewSDKContext(jm.ctx))
+
+	// 1. Stop submitting executions.
+	jm.stopSubmit()
+
+	// 2. Drain the in-flight executions.
+	logger.Info("draining in-flight executions", "timeout", cfg.DrainTimeout)
+	drainCtx, cancelDrain := context.WithTimeout(context.Background(), cfg.DrainTimeout)
+	defer cancelDrain()
+	jm.drain(drainCtx)
+	for key, e := range jm.executorsSnapshot() {
+		if inflight := e.inflightCount.Load(); inflight > 0 {
+			logger.Warn("abandoning in-flight executions", "job", key, "count", inflight)
+		}
+	}
+	if rejected := jm.rejected.Load(); rejected > 0 {
+		logger.Warn("rejected executions submitted during shutdown", "count", rejected)
+	}
+
+	// 3. Cancel the producers and wait for them to return.
+	if jm.cancelProducers != nil {
+		jm.cancelProducers()
+	}
+	producerCtx, cancelProducer := context.WithTimeout(context.Background(), cfg.ProducerTimeout)
+	defer cancelProducer()
+	for _, status := range jm.Jobs() {
+		if err := jm.waitProducer(producerCtx, status.Key); err != nil {
+			logger.Warn("abandoning producer that did not return", "job", status.Key)
+		}
+	}
+
+	// 4. Stop the worker pools.
+	jm.cancel()
+	jm.jobProducers.Stop()
+	jm.jobExecutors.Stop()
+	jm.stopDedicatedPools()
+
+	// 5. Tear down the jobs in reverse setup order.
+	return jm.teardown()
+}
+
+// drain waits for the in-flight executions of every job, repeatedly (as they may submit
+// executions of their downstream consumers), until there are none or the context is done.
+func (jm *JobManager) drain(ctx context.Context) {
+	for drained := false; !drained && ctx.Err() == nil; {
+		drained = true
+		for _, e := range jm.executorsSnapshot() {
+			if e.inflightCount.Load() > 0 {
+				drained = false
+				e.wait(ctx)
+			}
+		}
+	}
+}
+
+// executorsSnapshot returns a copy of the executors of jobs, by registry key.
+func (jm *JobManager) executorsSnapshot() map[string]*jobExecutor {
+	jm.executorsMu.Lock()
+	defer jm.executorsMu.Unlock()
+	executors := make(map[string]*jobExecutor, len(jm.executors))
+	for key, e := range jm.executors {
+		executors[key] = e
+	}
+	return executors
+}
+
+// waitProducer waits for the producer of the job with the given registry key to return, or for
+// the context to be done.
+func (jm *JobManager) waitProducer(ctx context.Context, key string) error {
+	s, err := jm.state(key)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	done := s.done
+	s.mu.Unlock()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// teardown tears down the jobs (and consumers) in reverse setup order, or in reverse registration
+// order if they were never run, collecting the errors.
+func (jm *JobManager) teardown() error {
+	jm.registryMu.Lock()
+	defer jm.registryMu.Unlock()
+
+	jobs := slices.Clone(jm.setupOrder)
+	if jm.runCtx == nil {
+		orderedJobs, err := jm.jobRegistry.IterateInOrder()
+		if err != nil {
+			return err
+		}
+		for _, key := range orderedJobs.Keys() {
+			jobs = append(jobs, jm.jobRegistry.Get(key))
+		}
+	}
+
+	var errs []error
+	for i := len(jobs) - 1; i >= 0; i-- {
+		j := jobs[i]
+		if tj, ok := j.(job.HasTeardown); ok {
+			if err := tj.Teardown(); err != nil {
+				errs = append(errs, fmt.Errorf("tearing down job %s: %w", j.RegistryKey(), err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
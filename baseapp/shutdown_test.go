@@ -0,0 +1,83 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+// slowJob is a polling job whose executions take a while, recording whether they completed and
+// the order of teardowns.
+type slowJob struct {
+	key       string
+	started   chan struct{}
+	once      sync.Once
+	completed chan error
+	teardowns *[]string
+}
+
+func (j *slowJob) RegistryKey() string { return j.key }
+
+func (*slowJob) IntervalTime(context.Context) time.Duration { return time.Millisecond }
+
+func (j *slowJob) Execute(ctx context.Context, _ any) (any, error) {
+	first := false
+	j.once.Do(func() { first = true })
+	if !first {
+		return nil, nil
+	}
+	close(j.started)
+	select {
+	case <-time.After(50 * time.Millisecond):
+		j.completed <- nil
+	case <-ctx.Done():
+		j.completed <- ctx.Err()
+	}
+	return nil, nil
+}
+
+func (j *slowJob) Teardown() error {
+	*j.teardowns = append(*j.teardowns, j.key)
+	return errors.New(j.key + " failed")
+}
+
+func TestShutdown(t *testing.T) {
+	var teardowns []string
+	newJob := func(key string) *slowJob {
+		return &slowJob{
+			key: key, started: make(chan struct{}), completed: make(chan error, 1),
+			teardowns: &teardowns,
+		}
+	}
+	first, second := newJob("first"), newJob("second")
+
+	jm := NewManager(
+		[]job.Basic{first, second}, nil, worker.Config{},
+		&contextFactory{logger: log.NewLogger(os.Stdout, "test-runner")},
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	jm.Start(ctx)
+	jm.RunProducers(ctx)
+	<-first.started
+	<-second.started
+
+	// Cancelling the app context does not cancel in-flight executions, which are drained.
+	cancel()
+	err := jm.Stop()
+	require.NoError(t, <-first.completed)
+	require.NoError(t, <-second.completed)
+
+	// Jobs are torn down in reverse order, collecting errors.
+	require.Equal(t, []string{"second", "first"}, teardowns)
+	require.ErrorContains(t, err, "first failed")
+	require.ErrorContains(t, err, "second failed")
+	require.ErrorIs(t, jm.Trigger("first", nil), ErrStopping)
+}
@@ -0,0 +1,122 @@
+package baseapp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/berachain/offchain-sdk/job"
+	workertypes "github.com/berachain/offchain-sdk/job/types"
+	sdk "github.com/berachain/offchain-sdk/types"
+)
+
+// errSubscriptionClosed is the error of a subscription whose channel was closed.
+var errSubscriptionClosed = errors.New("subscription closed")
+
+// subscription returns the producer of the given subscription job, which subscribes with the
+// given function (returning the channels of values and errors of the subscription, and the
+// function to unsubscribe), and resubscribes with backoff when the subscription fails or is
+// closed.
+func subscription[T any](
+	ctx *sdk.Context, jm *JobManager, j job.Basic,
+	subscribe func() (<-chan T, <-chan error, func(), error),
+) func() {
+	key := j.RegistryKey()
+	return withRetry(ctx, func() bool {
+		ch, errs, unsubscribe, err := subscribe()
+		if err != nil {
+			jm.Logger(ctx).Error("error subscribing", "job", key, "err", err)
+			jm.setSubscribed(key, err)
+			return true
+		}
+		jm.Logger(ctx).Info("(re)subscribed", "job", key)
+		jm.setSubscribed(key, nil)
+		defer unsubscribe()
+
+		err = forward(ctx, jm, j, ch, errs)
+		jm.setSubscribed(key, err)
+		return err != nil
+	}, jm.Logger(ctx))
+}
+
+// forward submits the values received from a subscription of the given job to its executor, until
+// the context is done (returning nil), or the subscription fails or its channel is closed
+// (returning the error, to resubscribe). Values are buffered according to the job's execution
+// config.
+func forward[T any](
+	ctx *sdk.Context, jm *JobManager, j job.Basic, ch <-chan T, errs <-chan error,
+) error {
+	executor := jm.executorFor(j)
+	submit := func(val T) {
+		executor.submit(val, workertypes.NewPayload(ctx, j, val).
+			WithSubmitter(executor.SubmitJob).WithObserver(jm).Execute)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	size := jm.executionConfig(j, j.RegistryKey()).BufferSize
+	ch = buffer(subCtx, ch, int(size))
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if err == nil {
+				err = errSubscriptionClosed
+			}
+			jm.Logger(ctx).Error("error in subscription", "job", j.RegistryKey(), "err", err)
+			// Submit the values already buffered before resubscribing.
+			if cancel(); size > 0 {
+				for val := range ch {
+					submit(val)
+				}
+			}
+			return err
+		case val, ok := <-ch:
+			if !ok {
+				jm.Logger(ctx).Error("subscription closed", "job", j.RegistryKey())
+				return errSubscriptionClosed
+			}
+			submit(val)
+		}
+	}
+}
+
+// setSubscribed records the error of the subscription of the job with the given registry key, or
+// nil if it is subscribed.
+func (jm *JobManager) setSubscribed(key string, err error) {
+	if s, stateErr := jm.state(key); stateErr == nil {
+		s.mu.Lock()
+		s.subErr = err
+		s.mu.Unlock()
+	}
+}
+
+// buffer returns a channel of the given size that receives the values of the given channel, until
+// the context is done or the given channel is closed, and is then closed. It returns the given
+// channel itself if size is 0.
+func buffer[T any](ctx context.Context, ch <-chan T, size int) <-chan T {
+	if size == 0 {
+		return ch
+	}
+	buf := make(chan T, size)
+	go func() {
+		defer close(buf)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case val, ok := <-ch:
+				if !ok {
+					return
+				}
+				select {
+				case buf <- val:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return buf
+}
@@ -0,0 +1,91 @@
+package baseapp
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	"github.com/stretchr/testify/require"
+)
+
+// subJob is a subscribable job whose first subscription is closed after sending its values.
+type subJob struct {
+	cfg           job.ExecutionConfig
+	subscriptions atomic.Int32
+	executions    atomic.Int32
+	sent          atomic.Int32
+}
+
+func (*subJob) RegistryKey() string { return "sub" }
+
+func (j *subJob) ExecutionConfig() job.ExecutionConfig { return j.cfg }
+
+func (j *subJob) Subscribe(ctx context.Context) chan any {
+	ch := make(chan any)
+	first := j.subscriptions.Add(1) == 1
+	go func() {
+		if first {
+			defer close(ch)
+		}
+		for i := 0; i < 3; i++ {
+			select {
+			case ch <- i:
+				j.sent.Add(1)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+func (j *subJob) Execute(context.Context, any) (any, error) {
+	j.executions.Add(1)
+	return nil, nil
+}
+
+func runProducer(t *testing.T, jm *JobManager, j job.Basic) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(jm.ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		jm.producer(jm.ctxFactory.NewSDKContext(ctx), j)()
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+}
+
+func TestSubscribableResubscribes(t *testing.T) {
+	j := &subJob{}
+	jm := newTestManager(t, "test_resubscribe", j)
+	runProducer(t, jm, j)
+
+	// The subscription is unhealthy until resubscribed.
+	require.Eventually(t, func() bool {
+		return jm.CheckSubscriptions(context.Background()) != nil
+	}, time.Second, time.Millisecond)
+	require.Eventually(t, func() bool { return j.executions.Load() == 6 }, 5*time.Second,
+		time.Millisecond)
+	require.Equal(t, int32(2), j.subscriptions.Load())
+	require.NoError(t, jm.CheckSubscriptions(context.Background()))
+}
+
+func TestSubscribableBuffer(t *testing.T) {
+	j := &subJob{cfg: job.ExecutionConfig{BufferSize: 2}}
+	jm := newTestManager(t, "test_buffer", j)
+	require.NoError(t, jm.Pause(j.RegistryKey()))
+	runProducer(t, jm, j)
+
+	// While the job is paused, the subscription is read into the buffer.
+	require.Eventually(t, func() bool { return j.sent.Load() == 3 }, time.Second, time.Millisecond)
+	require.Zero(t, j.executions.Load())
+
+	require.NoError(t, jm.Resume(j.RegistryKey()))
+	require.Eventually(t, func() bool { return j.executions.Load() == 3 }, time.Second,
+		time.Millisecond)
+}
@@ -3,7 +3,9 @@ package eth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -20,18 +22,41 @@ var (
 // ChainProviderImpl is an implementation of the ChainProvider interface.
 type ChainProviderImpl struct {
 	ConnectionPool
-	rpcTimeout time.Duration
+	rpcTimeout   time.Duration
+	pollInterval time.Duration
+
+	// polledHeads are the last blocks whose logs were delivered by the polling log subscriptions,
+	// by filter query, so that resubscribing resumes from them.
+	polledHeads   map[string]uint64
+	polledHeadsMu sync.Mutex
 }
 
 // NewChainProviderImpl creates a new ChainProviderImpl with the given ConnectionPool.
 func NewChainProviderImpl(pool ConnectionPool, cfg ConnectionPoolConfig) (Client, error) {
-	c := &ChainProviderImpl{ConnectionPool: pool, rpcTimeout: cfg.DefaultTimeout}
+	c := &ChainProviderImpl{
+		ConnectionPool: pool, rpcTimeout: cfg.DefaultTimeout, pollInterval: cfg.PollInterval,
+		polledHeads: make(map[string]uint64),
+	}
 	if c.rpcTimeout == 0 {
 		c.rpcTimeout = defaultRPCTimeout
 	}
+	if c.pollInterval == 0 {
+		c.pollInterval = defaultPollInterval
+	}
 	return c, nil
 }
 
+// CheckHealth implements health.Checker, checking the health of the connection pool (if it
+// checks its own health).
+func (c *ChainProviderImpl) CheckHealth(ctx context.Context) error {
+	if checker, ok := c.ConnectionPool.(interface {
+		CheckHealth(context.Context) error
+	}); ok {
+		return checker.CheckHealth(ctx)
+	}
+	return nil
+}
+
 // ==================================================================
 // Implementations of Reader and Writer
 // ==================================================================
@@ -69,7 +94,9 @@ func (c *ChainProviderImpl) TransactionReceipt(
 	return nil, ErrClientNotFound
 }
 
-// SubscribeNewHead subscribes to new head events.
+// SubscribeNewHead subscribes to new head events. If no WS client is configured or healthy, new
+// heads are polled over HTTP instead, until a WS client is healthy again: the subscription then
+// fails with ErrWSAvailable, to be resubscribed over WS.
 func (c *ChainProviderImpl) SubscribeNewHead(
 	ctx context.Context) (chan *types.Header, ethereum.Subscription, error) {
 	if client, ok := c.GetWS(); ok {
@@ -77,6 +104,11 @@ func (c *ChainProviderImpl) SubscribeNewHead(
 		defer cancel()
 		return client.SubscribeNewHead(ctxWithTimeout)
 	}
+	if _, ok := c.GetHTTP(); ok {
+		return subscribePollingHeads(ctx, c, pollingConfig{
+			interval: c.pollInterval, stop: c.wsAvailable,
+		})
+	}
 	return nil, nil, ErrClientNotFound
 }
 
@@ -199,18 +231,54 @@ func (c *ChainProviderImpl) SendTransaction(
 	return ErrClientNotFound
 }
 
-// SubscribeFilterLogs subscribes to new log events that satisfy the given filter query.
+// SubscribeFilterLogs subscribes to new log events that satisfy the given filter query. If no WS
+// client is configured or healthy, new logs are polled over HTTP instead, until a WS client is
+// healthy again: the subscription then fails with ErrWSAvailable, to be resubscribed over WS.
+// Resubscribing to the same query over HTTP resumes polling from the last block polled.
+//
+// NOTE: the logs emitted while switching from polling to WS are not delivered, unless replayed by
+// the subscriber (e.g. jobs with backfill enabled).
 func (c *ChainProviderImpl) SubscribeFilterLogs(
 	ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log,
 ) (ethereum.Subscription, error) {
+	key := fmt.Sprint(q.BlockHash, q.Addresses, q.Topics)
 	if client, ok := c.GetWS(); ok {
+		c.polledHeadsMu.Lock()
+		delete(c.polledHeads, key)
+		c.polledHeadsMu.Unlock()
+
 		ctxWithTimeout, cancel := context.WithTimeout(ctx, c.rpcTimeout)
 		defer cancel()
 		return client.SubscribeFilterLogs(ctxWithTimeout, q, ch)
 	}
+	if _, ok := c.GetHTTP(); ok {
+		return subscribePollingLogs(ctx, c, q, ch, pollingConfig{
+			interval: c.pollInterval,
+			head: func() (uint64, bool) {
+				c.polledHeadsMu.Lock()
+				defer c.polledHeadsMu.Unlock()
+				head, found := c.polledHeads[key]
+				return head, found
+			},
+			setHead: func(head uint64) {
+				c.polledHeadsMu.Lock()
+				defer c.polledHeadsMu.Unlock()
+				c.polledHeads[key] = head
+			},
+			stop: c.wsAvailable,
+		})
+	}
 	return nil, ErrClientNotFound
 }
 
+// wsAvailable returns ErrWSAvailable if a WS client is healthy.
+func (c *ChainProviderImpl) wsAvailable() error {
+	if _, ok := c.GetWS(); ok {
+		return ErrWSAvailable
+	}
+	return nil
+}
+
 // SuggestGasPrice suggests a gas price.
 func (c *ChainProviderImpl) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
 	if client, ok := c.GetHTTP(); ok {
@@ -315,13 +383,11 @@ func (c *ChainProviderImpl) TxPoolInspect(ctx context.Context) (
 	return nil, ErrClientNotFound
 }
 
+// Health returns whether a HTTP client is healthy. WS clients are not required, as subscriptions
+// fall back to polling over HTTP without them.
 func (c *ChainProviderImpl) Health() bool {
-	httpOk, wsOk := false, false
 	if client, ok := c.GetHTTP(); ok {
-		httpOk = client.Healthy()
-	}
-	if client, ok := c.GetWS(); ok {
-		wsOk = client.Healthy()
+		return client.Healthy()
 	}
-	return httpOk && wsOk
+	return false
 }
@@ -5,6 +5,7 @@ import "time"
 const (
 	defaultRPCTimeout          = 5 * time.Second
 	defaultHealthCheckInterval = 5 * time.Second
+	defaultPollInterval        = 2 * time.Second
 )
 
 type ConnectionPoolConfig struct {
@@ -12,6 +13,9 @@ type ConnectionPoolConfig struct {
 	EthWSURLs           []string
 	DefaultTimeout      time.Duration
 	HealthCheckInterval time.Duration
+	// PollInterval is how often the chain is polled over HTTP by the log and new head
+	// subscriptions, when no WS endpoint is configured or healthy. Defaults to 2s.
+	PollInterval time.Duration
 }
 
 func DefaultConnectPoolConfig() *ConnectionPoolConfig {
@@ -20,5 +24,6 @@ func DefaultConnectPoolConfig() *ConnectionPoolConfig {
 		EthWSURLs:           []string{"ws://localhost:8546"},
 		DefaultTimeout:      defaultRPCTimeout,
 		HealthCheckInterval: defaultHealthCheckInterval,
+		PollInterval:        defaultPollInterval,
 	}
 }
@@ -97,26 +97,33 @@ func (c *ConnectionPoolImpl) DialContext(ctx context.Context, _ string) error {
 	return nil
 }
 
+// GetHTTP returns the oldest healthy HTTP client, or the oldest HTTP client if none is healthy.
 func (c *ConnectionPoolImpl) GetHTTP() (*HealthCheckedClient, bool) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-retry:
-	_, client, ok := c.cache.GetOldest()
-	if !client.Healthy() {
-		goto retry
+	if client, ok := healthiest(c.cache.Values()); ok {
+		return client, true
 	}
+	_, client, ok := c.cache.GetOldest()
 	return client, ok
 }
 
+// GetWS returns the oldest healthy WS client. It returns false if no WS client is configured, or
+// none is healthy.
 func (c *ConnectionPoolImpl) GetWS() (*HealthCheckedClient, bool) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-retry:
-	_, client, ok := c.wsCache.GetOldest()
-	if !client.Healthy() {
-		goto retry
+	return healthiest(c.wsCache.Values())
+}
+
+// healthiest returns the first healthy client of the given clients, if any.
+func healthiest(clients []*HealthCheckedClient) (*HealthCheckedClient, bool) {
+	for _, client := range clients {
+		if client.Healthy() {
+			return client, true
+		}
 	}
-	return client, ok
+	return nil, false
 }
 
 func (c *ConnectionPoolImpl) RemoveChainClient(clientAddr string) error {
@@ -133,3 +140,25 @@ func (c *ConnectionPoolImpl) removeClient(clientAddr string) error {
 	client.Close()
 	return nil
 }
+
+// CheckHealth implements health.Checker, failing if none of the HTTP endpoints is healthy. The WS
+// endpoints are not checked, as subscriptions fall back to polling over HTTP without them.
+func (c *ConnectionPoolImpl) CheckHealth(context.Context) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return checkEndpoints("http", c.cache.Values())
+}
+
+// checkEndpoints returns an error if none of the given clients is healthy.
+func checkEndpoints(scheme string, clients []*HealthCheckedClient) error {
+	healthy := 0
+	for _, client := range clients {
+		if client.Healthy() {
+			healthy++
+		}
+	}
+	if healthy == 0 {
+		return fmt.Errorf("0/%d %s endpoints healthy", len(clients), scheme)
+	}
+	return nil
+}
@@ -41,6 +41,9 @@ func (c *HealthCheckedClient) DialContext(
 	c.ExtendedEthClient = NewExtendedEthClient(ethClient, rpcTimeout)
 	c.dialurl = rawurl
 
+	// Check the health once before returning, so that the client is not considered unhealthy
+	// (e.g. making subscriptions fall back to polling) until the first periodic check.
+	c.checkHealth(ctx)
 	go c.StartHealthCheck(ctx)
 
 	return nil
@@ -58,23 +61,29 @@ func (c *HealthCheckedClient) SetHealthy(healthy bool) {
 	c.healthy = healthy
 }
 
+// StartHealthCheck checks the health of the client at every health check interval, until the
+// context is done.
 func (c *HealthCheckedClient) StartHealthCheck(ctx context.Context) {
 	for {
 		select {
 		case <-ctx.Done():
 			return
-		default:
-			ctxWithTimeout, cancel := context.WithTimeout(ctx, c.rpcTimeout)
-			_, err := c.ChainID(ctxWithTimeout)
-			cancel()
-			if err != nil {
-				c.SetHealthy(false)
-				c.logger.Error("eth client reporting unhealthy", "err", err, "url", c.dialurl)
-			} else {
-				c.SetHealthy(true)
-				c.logger.Info("eth client reporting healthy", "url", c.dialurl)
-			}
+		case <-time.After(c.healthCheckInterval):
+			c.checkHealth(ctx)
 		}
-		time.Sleep(c.healthCheckInterval)
+	}
+}
+
+// checkHealth checks the health of the client, by fetching the chain ID.
+func (c *HealthCheckedClient) checkHealth(ctx context.Context) {
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, c.rpcTimeout)
+	_, err := c.ChainID(ctxWithTimeout)
+	cancel()
+	if err != nil {
+		c.SetHealthy(false)
+		c.logger.Error("eth client reporting unhealthy", "err", err, "url", c.dialurl)
+	} else {
+		c.SetHealthy(true)
+		c.logger.Info("eth client reporting healthy", "url", c.dialurl)
 	}
 }
@@ -0,0 +1,185 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const (
+	defaultPollChunkSize = 1000
+	maxPollFailures      = 5
+)
+
+// ErrWSAvailable ends the polling subscriptions once a WS client is healthy again, so that they
+// are resubscribed over WS.
+var ErrWSAvailable = errors.New("ws client available, resubscribe")
+
+// pollingReader is the reader that polling subscriptions poll the chain with.
+type pollingReader interface {
+	BlockNumber(ctx context.Context) (uint64, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// pollingConfig configures a polling subscription.
+type pollingConfig struct {
+	// interval is how often the chain is polled.
+	interval time.Duration
+	// chunkSize is the max number of blocks queried per FilterLogs call. Defaults to 1000.
+	chunkSize uint64
+	// head (optional) returns the last block whose logs were delivered by a previous subscription,
+	// if any, to resume from.
+	head func() (uint64, bool)
+	// setHead (optional) is called with the last block whose logs were delivered.
+	setHead func(uint64)
+	// stop (optional) returns an error to end the subscription with, e.g. ErrWSAvailable.
+	stop func() error
+}
+
+// subscribePollingLogs subscribes to the new logs that satisfy the given filter query, by polling
+// the head of the chain at the given interval and querying the logs of the new blocks with
+// FilterLogs, in chunks. Unlike WS subscriptions, logs removed by reorgs are not notified. Polling
+// errors are retried at the next interval, and the subscription fails if polling fails too many
+// times in a row.
+func subscribePollingLogs(
+	ctx context.Context, reader pollingReader, q ethereum.FilterQuery, ch chan<- types.Log,
+	cfg pollingConfig,
+) (ethereum.Subscription, error) {
+	if cfg.chunkSize == 0 {
+		cfg.chunkSize = defaultPollChunkSize
+	}
+
+	var (
+		head uint64
+		ok   bool
+		err  error
+	)
+	if cfg.head != nil {
+		head, ok = cfg.head()
+	}
+	if !ok {
+		if head, err = reader.BlockNumber(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	return newPollingSubscription(ctx, cfg, func(ctx context.Context) error {
+		latest, err := reader.BlockNumber(ctx)
+		if err != nil {
+			return err
+		}
+		for head < latest {
+			rangeQuery := q
+			rangeQuery.FromBlock = new(big.Int).SetUint64(head + 1)
+			rangeQuery.ToBlock = new(big.Int).SetUint64(min(head+cfg.chunkSize, latest))
+			logs, err := reader.FilterLogs(ctx, rangeQuery)
+			if err != nil {
+				return err
+			}
+			for _, l := range logs {
+				select {
+				case <-ctx.Done():
+					return nil
+				case ch <- l:
+				}
+			}
+			head = rangeQuery.ToBlock.Uint64()
+			if cfg.setHead != nil {
+				cfg.setHead(head)
+			}
+		}
+		return nil
+	}), nil
+}
+
+// subscribePollingHeads subscribes to new heads, by polling the latest header at the given
+// interval. Every block since the last head is sent, in order; on a reorg, the new head is sent.
+// Polling errors are retried at the next interval, and the subscription fails if polling fails
+// too many times in a row.
+func subscribePollingHeads(
+	ctx context.Context, reader pollingReader, cfg pollingConfig,
+) (chan *types.Header, ethereum.Subscription, error) {
+	head, err := reader.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ch := make(chan *types.Header)
+	send := func(ctx context.Context, h *types.Header) bool {
+		select {
+		case <-ctx.Done():
+			return false
+		case ch <- h:
+			return true
+		}
+	}
+	return ch, newPollingSubscription(ctx, cfg, func(ctx context.Context) error {
+		latest, err := reader.HeaderByNumber(ctx, nil)
+		if err != nil || latest.Hash() == head.Hash() {
+			return err
+		}
+		for n := head.Number.Uint64() + 1; n < latest.Number.Uint64(); n++ {
+			h, err := reader.HeaderByNumber(ctx, new(big.Int).SetUint64(n))
+			if err != nil {
+				return err
+			}
+			if !send(ctx, h) {
+				return nil
+			}
+			head = h
+		}
+		if send(ctx, latest) {
+			head = latest
+		}
+		return nil
+	}), nil
+}
+
+// newPollingSubscription returns a subscription that calls poll at the configured interval, until
+// it is unsubscribed (or the context is done), poll fails too many times in a row, or the config's
+// stop returns an error. The context passed to poll is done when the subscription is unsubscribed.
+func newPollingSubscription(
+	ctx context.Context, cfg pollingConfig, poll func(context.Context) error,
+) ethereum.Subscription {
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(cfg.interval)
+		defer ticker.Stop()
+		var failures int
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				if cfg.stop != nil {
+					if err := cfg.stop(); err != nil {
+						return err
+					}
+				}
+				err := poll(ctx)
+				if err == nil || ctx.Err() != nil {
+					failures = 0
+					continue
+				}
+				if failures++; failures >= maxPollFailures {
+					return err
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// fakeReader serves the blocks of a fake chain, with a log per block.
+type fakeReader struct {
+	mu      sync.Mutex
+	headers []*types.Header
+	// failures is the number of the next calls to FilterLogs that fail.
+	failures int
+	// ranges are the block ranges queried by FilterLogs.
+	ranges [][2]uint64
+}
+
+// mine appends n blocks to the chain.
+func (r *fakeReader) mine(n int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := 0; i < n; i++ {
+		h := &types.Header{Number: big.NewInt(int64(len(r.headers)))}
+		if len(r.headers) > 0 {
+			h.ParentHash = r.headers[len(r.headers)-1].Hash()
+		}
+		r.headers = append(r.headers, h)
+	}
+}
+
+func (r *fakeReader) BlockNumber(context.Context) (uint64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return uint64(len(r.headers) - 1), nil
+}
+
+func (r *fakeReader) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.failures > 0 {
+		r.failures--
+		return nil, errors.New("transient error")
+	}
+	r.ranges = append(r.ranges, [2]uint64{q.FromBlock.Uint64(), q.ToBlock.Uint64()})
+
+	var logs []types.Log
+	for n := q.FromBlock.Uint64(); n <= q.ToBlock.Uint64(); n++ {
+		logs = append(logs, types.Log{BlockNumber: n})
+	}
+	return logs, nil
+}
+
+func (r *fakeReader) HeaderByNumber(_ context.Context, n *big.Int) (*types.Header, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if n == nil {
+		return r.headers[len(r.headers)-1], nil
+	}
+	return r.headers[n.Uint64()], nil
+}
+
+func TestPollingSubscriptions(t *testing.T) {
+	reader := &fakeReader{}
+	reader.mine(3)
+
+	logs := make(chan types.Log)
+	logSub, err := subscribePollingLogs(
+		context.Background(), reader, ethereum.FilterQuery{}, logs,
+		pollingConfig{interval: time.Millisecond},
+	)
+	require.NoError(t, err)
+	defer logSub.Unsubscribe()
+	heads, headSub, err := subscribePollingHeads(
+		context.Background(), reader, pollingConfig{interval: time.Millisecond},
+	)
+	require.NoError(t, err)
+	defer headSub.Unsubscribe()
+
+	// Every block after the head at subscription time is delivered, in order.
+	reader.mine(3)
+	for n := uint64(3); n < 6; n++ {
+		select {
+		case l := <-logs:
+			require.Equal(t, n, l.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatalf("log of block %d not delivered", n)
+		}
+		select {
+		case h := <-heads:
+			require.Equal(t, n, h.Number.Uint64())
+		case <-time.After(time.Second):
+			t.Fatalf("header of block %d not delivered", n)
+		}
+	}
+}
+
+// receiveLogs receives the logs of the given blocks, in order.
+func receiveLogs(t *testing.T, logs <-chan types.Log, from, to uint64) {
+	t.Helper()
+	for n := from; n <= to; n++ {
+		select {
+		case l := <-logs:
+			require.Equal(t, n, l.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatalf("log of block %d not delivered", n)
+		}
+	}
+}
+
+func TestPollingLogsChunksRetriesAndResumes(t *testing.T) {
+	reader := &fakeReader{}
+	reader.mine(1)
+
+	var (
+		mu     sync.Mutex
+		polled uint64
+	)
+	cfg := pollingConfig{
+		interval:  time.Millisecond,
+		chunkSize: 2,
+		head: func() (uint64, bool) {
+			mu.Lock()
+			defer mu.Unlock()
+			return polled, polled > 0
+		},
+		setHead: func(head uint64) {
+			mu.Lock()
+			defer mu.Unlock()
+			polled = head
+		},
+	}
+	logs := make(chan types.Log)
+	sub, err := subscribePollingLogs(
+		context.Background(), reader, ethereum.FilterQuery{}, logs, cfg,
+	)
+	require.NoError(t, err)
+
+	// Transient errors are retried, and the new blocks are queried in chunks.
+	reader.mu.Lock()
+	reader.failures = maxPollFailures - 1
+	reader.mu.Unlock()
+	reader.mine(5)
+	receiveLogs(t, logs, 1, 5)
+	reader.mu.Lock()
+	require.Equal(t, [][2]uint64{{1, 2}, {3, 4}, {5, 5}}, reader.ranges)
+	reader.mu.Unlock()
+
+	// Resubscribing resumes from the last block polled.
+	sub.Unsubscribe()
+	reader.mine(2)
+	sub, err = subscribePollingLogs(
+		context.Background(), reader, ethereum.FilterQuery{}, logs, cfg,
+	)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	receiveLogs(t, logs, 6, 7)
+}
+
+func TestPollingFailsWhenStopped(t *testing.T) {
+	reader := &fakeReader{}
+	reader.mine(1)
+
+	// The subscription fails once stop returns an error, e.g. when a WS client is available.
+	_, sub, err := subscribePollingHeads(context.Background(), reader, pollingConfig{
+		interval: time.Millisecond,
+		stop:     func() error { return ErrWSAvailable },
+	})
+	require.NoError(t, err)
+	select {
+	case err = <-sub.Err():
+		require.ErrorIs(t, err, ErrWSAvailable)
+	case <-time.After(time.Second):
+		t.Fatal("subscription not stopped")
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
@@ -15,6 +16,8 @@ import (
 	coreapp "github.com/berachain/offchain-sdk/core/app"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/tools/leader"
 	"github.com/spf13/cobra"
 )
 
@@ -65,8 +68,22 @@ func StartCmdWithOptions[C any](
 				configPath, &cfg, envOverride, envOverridePrefix); err != nil {
 				return err
 			}
+			if err = cfg.Workers.Validate(); err != nil {
+				return fmt.Errorf("invalid config: %w", err)
+			}
 
 			ab := baseapp.NewAppBuilder(app.Name())
+			ab.RegisterJobConfigs(cfg.Jobs)
+			ab.RegisterWorkers(cfg.Workers)
+			ab.RegisterShutdown(cfg.Shutdown)
+
+			// Register the metrics and health reporting (which the app may override in Setup).
+			metrics, err := telemetry.NewMetrics(&cfg.Telemetry)
+			if err != nil {
+				return err
+			}
+			ab.RegisterMetrics(metrics)
+			ab.RegisterHealthReportInterval(cfg.Telemetry.HealthReportInterval)
 
 			logger := log.NewWithCfg(cmd.OutOrStdout(), app.Name(), cfg.Log)
 			// // Maybe move this to BuildApp?
@@ -93,6 +110,11 @@ func StartCmdWithOptions[C any](
 
 			ab.RegisterEthClient(cpi)
 
+			// Register the leader elector if enabled.
+			if cfg.LeaderElection.Enabled {
+				ab.RegisterLeaderElector(leader.NewFromConfig(cfg.LeaderElection, logger))
+			}
+
 			// Register the HTTP server if enabled.
 			if cfg.Server.HTTP.Enabled() {
 				svr := server.New(&cfg.Server, logger)
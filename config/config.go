@@ -2,8 +2,12 @@ package config
 
 import (
 	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/tools/leader"
+	"github.com/berachain/offchain-sdk/worker"
 )
 
 // Reader defines an interface for reading in configuration data.
@@ -21,6 +25,23 @@ type Config[C any] struct {
 	// Server Config
 	Server server.Config
 
+	// Workers Config
+	Workers worker.Config
+
+	// LeaderElection Config
+	LeaderElection leader.Config
+
+	// Shutdown Config
+	Shutdown ShutdownConfig
+
+	// Jobs are the execution configs of jobs, by registry key, which override those declared by
+	// the jobs.
+	Jobs map[string]job.ExecutionConfig
+
 	// Log Config
 	Log log.Config
+
+	// Telemetry config of the metrics the job manager records to and of the health reports.
+	// Apps may register their own metrics in Setup instead.
+	Telemetry telemetry.Config
 }
@@ -0,0 +1,13 @@
+package config
+
+import "time"
+
+// ShutdownConfig configures the shutdown sequence of the job manager.
+type ShutdownConfig struct {
+	// DrainTimeout is how long to wait for in-flight executions to finish, after which they are
+	// abandoned (and their contexts cancelled). Defaults to 30s.
+	DrainTimeout time.Duration
+	// ProducerTimeout is how long to wait for the producers to return (e.g. unsubscribing from
+	// eth subscriptions) once they are cancelled. Defaults to 5s.
+	ProducerTimeout time.Duration
+}
@@ -1,10 +1,14 @@
 package app
 
 import (
+	"time"
+
 	"github.com/berachain/offchain-sdk/baseapp"
 	"github.com/berachain/offchain-sdk/job"
 	"github.com/berachain/offchain-sdk/log"
 	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/berachain/offchain-sdk/telemetry/health"
 
 	"github.com/ethereum/go-ethereum/ethdb"
 )
@@ -18,4 +22,8 @@ type Builder interface {
 	RegisterHTTPHandler(handler *server.Handler) error
 	RegisterMiddleware(m server.Middleware) error
 	RegisterPrometheusTelemetry() error
+	RegisterJobControl() error
+	RegisterMetrics(metrics telemetry.Metrics)
+	RegisterHealthReportInterval(interval time.Duration)
+	Health() *health.Registry
 }
@@ -3,6 +3,9 @@ package transactor
 import (
 	"time"
 
+	"github.com/berachain/offchain-sdk/core/transactor/notify"
+	"github.com/berachain/offchain-sdk/types/queue/db"
+	"github.com/berachain/offchain-sdk/types/queue/redis"
 	"github.com/berachain/offchain-sdk/types/queue/sqs"
 )
 
@@ -28,13 +31,30 @@ type Config struct {
 	TxReceiptTimeout time.Duration
 	// Whether we should resend txs that are stale (not confirmed after the receipt timeout).
 	ResendStaleTxs bool
+	// How many times a tx request is taken from the queue before it is dropped, if its tx fails to
+	// build or send, or becomes stale without being resent. Failed requests are released back to
+	// the queue until then. Defaults to 3.
+	MaxRequestAttempts int
 
 	// How often to post a snapshot of the transactor system status (ideally 1 block time).
 	StatusUpdateInterval time.Duration
 
 	// (Optional) SQS queue config. If left empty, an in-memory queue is used.
 	SQS sqs.Config
+	// (Optional) Redis streams queue config, used if SQS is not configured. If left empty, an
+	// in-memory queue is used.
+	Redis redis.Config
+	// (Optional) Durable local (leveldb) queue config, used if neither SQS nor Redis are
+	// configured. If left empty, an in-memory queue is used.
+	LocalQueue db.Config
 	// If true, the queue (SQS generates its own) message ID will be used for tracking messages,
 	// rather than the optional, user-provided message ID.
 	UseQueueMessageID bool
+
+	// (Optional) Webhooks to POST signed tx outcomes to. If no URLs are set, no webhooks are sent.
+	Webhooks notify.WebhookConfig
+
+	// (Optional) Stream of tx outcomes as Server-Sent Events, served on the app's built-in HTTP
+	// server. If no path is set, outcomes are not streamed.
+	OutcomeStream notify.SSEConfig
 }
@@ -0,0 +1,47 @@
+package event
+
+const defaultBufferSize = 64
+
+// OverflowPolicy determines what the Dispatcher does when a subscriber's buffer is full.
+type OverflowPolicy uint8
+
+const (
+	// OverflowBlock waits until the subscriber has room in its buffer. NOTE: a slow subscriber
+	// with this policy will delay the delivery of events to all other subscribers.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest discards the oldest buffered event to make room for the new event.
+	OverflowDropOldest
+	// OverflowDisconnect unsubscribes the subscriber and closes its channel.
+	OverflowDisconnect
+)
+
+// String implements fmt.Stringer.
+func (p OverflowPolicy) String() string {
+	switch p {
+	case OverflowBlock:
+		return "block"
+	case OverflowDropOldest:
+		return "drop-oldest"
+	case OverflowDisconnect:
+		return "disconnect"
+	default:
+		return "unknown"
+	}
+}
+
+// SubscriberConfig is the configuration for delivering events to a single subscriber.
+type SubscriberConfig struct {
+	// BufferSize is the number of events that can be buffered for the subscriber before the
+	// overflow policy is applied.
+	BufferSize int
+	// Overflow is the policy applied when the subscriber's buffer is full.
+	Overflow OverflowPolicy
+}
+
+// DefaultSubscriberConfig is the default configuration for a subscriber, which never loses events.
+func DefaultSubscriberConfig() SubscriberConfig {
+	return SubscriberConfig{
+		BufferSize: defaultBufferSize,
+		Overflow:   OverflowBlock,
+	}
+}
@@ -1,34 +1,173 @@
 package event
 
-// Dispatcher is a generic event dispatcher. It maintains a mapping of unique indexes to
-// subscribers, which are channels that events are sent to.
+import (
+	"sort"
+	"strconv"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/telemetry"
+)
+
+// SubscriptionID uniquely identifies a subscriber of a Dispatcher. IDs are never reused.
+type SubscriptionID uint64
+
+// Dispatcher is a generic, thread-safe event dispatcher. It maintains a mapping of unique IDs to
+// subscribers, each of which receives events on its own buffered channel. Events are delivered to
+// every subscriber in the order in which they were dispatched.
 type Dispatcher[E any] struct {
-	subscribers map[int]chan E
+	metrics telemetry.Metrics
+
+	// dispatchMu serializes dispatches (and the closing of subscriber channels) so that every
+	// subscriber observes events in the same order.
+	dispatchMu sync.Mutex
+
+	mu          sync.RWMutex // guards the fields below
+	nextID      SubscriptionID
+	subscribers map[SubscriptionID]*subscriber[E]
+}
+
+// subscriber holds the delivery state of a single subscription.
+type subscriber[E any] struct {
+	id   SubscriptionID
+	cfg  SubscriberConfig
+	ch   chan E
+	tags []string
+
+	done     chan struct{} // closed when the subscriber is removed
+	doneOnce sync.Once
 }
 
-// NewDispatcher creates a new Dispatcher.
-func NewDispatcher[E any]() *Dispatcher[E] {
+// NewDispatcher creates a new Dispatcher that records delivery metrics to the given metrics.
+func NewDispatcher[E any](metrics telemetry.Metrics) *Dispatcher[E] {
 	return &Dispatcher[E]{
-		subscribers: make(map[int]chan E),
+		metrics:     metrics,
+		subscribers: make(map[SubscriptionID]*subscriber[E]),
 	}
 }
 
-// Subscribe adds a new subscriber to the Dispatcher. The subscriber is a channel on which events
-// will be sent to. Returns the unique index of the subscriber.
-func (d *Dispatcher[E]) Subscribe(subscriber chan E) int {
-	index := len(d.subscribers)
-	d.subscribers[index] = subscriber
-	return index
+// Subscribe adds a new subscriber to the Dispatcher with the given config. Returns the unique ID
+// of the subscriber and the channel on which events will be sent. The channel is closed once the
+// subscriber is unsubscribed or disconnected.
+func (d *Dispatcher[E]) Subscribe(cfg SubscriberConfig) (SubscriptionID, <-chan E) {
+	if cfg.BufferSize < 0 {
+		cfg.BufferSize = 0
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.nextID++
+	sub := &subscriber[E]{
+		id:   d.nextID,
+		cfg:  cfg,
+		ch:   make(chan E, cfg.BufferSize),
+		tags: []string{"subscription:" + strconv.FormatUint(uint64(d.nextID), 10)},
+		done: make(chan struct{}),
+	}
+	d.subscribers[sub.id] = sub
+	d.metrics.Gauge("dispatcher.subscribers", float64(len(d.subscribers)), nil, 1)
+
+	return sub.id, sub.ch
 }
 
-// Unsubscribe removes a subscriber from the Dispatcher at the given unique index.
-func (d *Dispatcher[E]) Unsubscribe(index int) {
-	delete(d.subscribers, index)
+// Unsubscribe removes the subscriber with the given unique ID from the Dispatcher and closes its
+// channel. It is a no-op if the subscriber does not exist.
+func (d *Dispatcher[E]) Unsubscribe(id SubscriptionID) {
+	sub := d.remove(id)
+	if sub == nil {
+		return
+	}
+
+	// Unblock any in-progress delivery to this subscriber before waiting to close its channel.
+	sub.doneOnce.Do(func() { close(sub.done) })
+	d.dispatchMu.Lock()
+	defer d.dispatchMu.Unlock()
+	close(sub.ch)
 }
 
-// Dispatch sends an event to all subscribers.
+// Dispatch sends an event to all subscribers, in the order that they subscribed. It only blocks
+// if a subscriber with the OverflowBlock policy has a full buffer.
 func (d *Dispatcher[E]) Dispatch(event E) {
-	for _, subscriber := range d.subscribers {
-		subscriber <- event
+	d.dispatchMu.Lock()
+	defer d.dispatchMu.Unlock()
+
+	for _, sub := range d.snapshot() {
+		d.deliver(sub, event)
+	}
+}
+
+// deliver sends the event to the subscriber, applying its overflow policy if the buffer is full.
+// NOTE: must be called while holding dispatchMu.
+func (d *Dispatcher[E]) deliver(sub *subscriber[E], event E) {
+	select {
+	case <-sub.done:
+		return
+	case sub.ch <- event:
+		d.recordDelivered(sub)
+		return
+	default:
+	}
+
+	switch sub.cfg.Overflow {
+	case OverflowBlock:
+		select {
+		case <-sub.done:
+		case sub.ch <- event:
+			d.recordDelivered(sub)
+		}
+	case OverflowDropOldest:
+		// Make room by discarding the oldest buffered event, which may race with the subscriber
+		// reading it; either way there is room for the new event afterwards.
+		select {
+		case <-sub.ch:
+			d.metrics.IncMonotonic("dispatcher.events.dropped", sub.tags)
+		default:
+		}
+		select {
+		case sub.ch <- event:
+			d.recordDelivered(sub)
+		default:
+			// Only possible with a zero-sized buffer and no ready receiver.
+			d.metrics.IncMonotonic("dispatcher.events.dropped", sub.tags)
+		}
+	case OverflowDisconnect:
+		if d.remove(sub.id) != nil {
+			sub.doneOnce.Do(func() { close(sub.done) })
+			close(sub.ch)
+			d.metrics.IncMonotonic("dispatcher.subscribers.disconnected", sub.tags)
+		}
+	}
+}
+
+// recordDelivered records the metrics for a successful delivery to the subscriber.
+func (d *Dispatcher[E]) recordDelivered(sub *subscriber[E]) {
+	d.metrics.IncMonotonic("dispatcher.events.delivered", sub.tags)
+	d.metrics.Gauge("dispatcher.buffer.depth", float64(len(sub.ch)), sub.tags, 1)
+}
+
+// snapshot returns the current subscribers, ordered by subscription ID.
+func (d *Dispatcher[E]) snapshot() []*subscriber[E] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	subs := make([]*subscriber[E], 0, len(d.subscribers))
+	for _, sub := range d.subscribers {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].id < subs[j].id })
+	return subs
+}
+
+// remove deletes the subscriber with the given ID, returning it if it existed.
+func (d *Dispatcher[E]) remove(id SubscriptionID) *subscriber[E] {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	sub, ok := d.subscribers[id]
+	if !ok {
+		return nil
 	}
+	delete(d.subscribers, id)
+	d.metrics.Gauge("dispatcher.subscribers", float64(len(d.subscribers)), nil, 1)
+	return sub
 }
@@ -0,0 +1,110 @@
+package event_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/core/transactor/event"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func newDispatcher(t *testing.T) *event.Dispatcher[int] {
+	metrics, err := telemetry.NewMetrics(&telemetry.Config{})
+	require.NoError(t, err)
+	return event.NewDispatcher[int](metrics)
+}
+
+func TestUniqueSubscriptionIDs(t *testing.T) {
+	d := newDispatcher(t)
+
+	id1, _ := d.Subscribe(event.DefaultSubscriberConfig())
+	id2, _ := d.Subscribe(event.DefaultSubscriberConfig())
+	d.Unsubscribe(id1)
+	id3, _ := d.Subscribe(event.DefaultSubscriberConfig())
+
+	require.NotEqual(t, id1, id2)
+	require.NotEqual(t, id1, id3)
+	require.NotEqual(t, id2, id3)
+}
+
+func TestOrderedDelivery(t *testing.T) {
+	d := newDispatcher(t)
+	_, ch1 := d.Subscribe(event.SubscriberConfig{BufferSize: 100})
+	_, ch2 := d.Subscribe(event.SubscriberConfig{BufferSize: 100})
+
+	for i := 0; i < 100; i++ {
+		d.Dispatch(i)
+	}
+
+	for i := 0; i < 100; i++ {
+		require.Equal(t, i, <-ch1)
+		require.Equal(t, i, <-ch2)
+	}
+}
+
+func TestDropOldest(t *testing.T) {
+	d := newDispatcher(t)
+	_, ch := d.Subscribe(event.SubscriberConfig{BufferSize: 2, Overflow: event.OverflowDropOldest})
+
+	for i := 0; i < 5; i++ {
+		d.Dispatch(i)
+	}
+
+	require.Equal(t, 3, <-ch)
+	require.Equal(t, 4, <-ch)
+}
+
+func TestDisconnect(t *testing.T) {
+	d := newDispatcher(t)
+	_, slow := d.Subscribe(event.SubscriberConfig{BufferSize: 1, Overflow: event.OverflowDisconnect})
+	_, fast := d.Subscribe(event.SubscriberConfig{BufferSize: 10})
+
+	for i := 0; i < 3; i++ {
+		d.Dispatch(i)
+	}
+
+	// The slow subscriber only receives the buffered event before its channel is closed.
+	require.Equal(t, 0, <-slow)
+	_, ok := <-slow
+	require.False(t, ok)
+
+	// The other subscriber is unaffected.
+	for i := 0; i < 3; i++ {
+		require.Equal(t, i, <-fast)
+	}
+}
+
+func TestUnsubscribeUnblocksDispatch(t *testing.T) {
+	d := newDispatcher(t)
+	id, _ := d.Subscribe(event.SubscriberConfig{BufferSize: 0, Overflow: event.OverflowBlock})
+
+	dispatched := make(chan struct{})
+	go func() {
+		defer close(dispatched)
+		d.Dispatch(1)
+	}()
+
+	d.Unsubscribe(id)
+	<-dispatched
+}
+
+func TestConcurrentSubscribeDispatch(_ *testing.T) {
+	metrics, _ := telemetry.NewMetrics(&telemetry.Config{})
+	d := event.NewDispatcher[int](metrics)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			id, _ := d.Subscribe(event.SubscriberConfig{Overflow: event.OverflowDropOldest})
+			d.Unsubscribe(id)
+		}()
+		go func(i int) {
+			defer wg.Done()
+			d.Dispatch(i)
+		}(i)
+	}
+	wg.Wait()
+}
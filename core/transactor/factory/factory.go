@@ -8,6 +8,7 @@ import (
 
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/core/transactor/sender"
+	"github.com/berachain/offchain-sdk/telemetry"
 	kmstypes "github.com/berachain/offchain-sdk/types/kms/types"
 
 	"github.com/ethereum/go-ethereum"
@@ -21,6 +22,7 @@ type Factory struct {
 	signer        kmstypes.TxSigner
 	signTxTimeout time.Duration
 	batcher       Batcher
+	metrics       telemetry.Metrics
 
 	// caches
 	ethClient     eth.Client
@@ -31,12 +33,14 @@ type Factory struct {
 // New creates a new factory instance.
 func New(
 	noncer Noncer, batcher Batcher, signer kmstypes.TxSigner, signTxTimeout time.Duration,
+	metrics telemetry.Metrics,
 ) *Factory {
 	return &Factory{
 		noncer:        noncer,
 		signer:        signer,
 		signTxTimeout: signTxTimeout,
 		batcher:       batcher,
+		metrics:       metrics,
 		signerAddress: signer.Address(),
 	}
 }
@@ -147,11 +151,18 @@ func (f *Factory) buildTransaction(
 	}
 
 	// sign the transaction
+	start := time.Now()
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, f.signTxTimeout)
 	signer, err := f.signer.SignerFunc(ctxWithTimeout, tx.ChainId())
 	cancel()
 	if err != nil {
+		f.metrics.IncMonotonic("transactor.sign.errors", nil)
 		return nil, err
 	}
-	return signer(f.signerAddress, tx)
+	if tx, err = signer(f.signerAddress, tx); err != nil {
+		f.metrics.IncMonotonic("transactor.sign.errors", nil)
+		return nil, err
+	}
+	f.metrics.Time("transactor.sign.duration", time.Since(start), nil)
+	return tx, nil
 }
@@ -2,10 +2,13 @@ package transactor
 
 import (
 	"context"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
+	queuetypes "github.com/berachain/offchain-sdk/types/queue/types"
 
 	"github.com/ethereum/go-ethereum"
 )
@@ -27,6 +30,7 @@ func (t *TxrV2) mainLoop(ctx context.Context) {
 			}
 
 			// We got a batch, so we can build and fire, after the previous fire has finished.
+			t.metrics.Histogram("transactor.batch.size", float64(len(requests)), nil, 1)
 			go t.fire(
 				ctx, &tracker.Response{MsgIDs: requests.MsgIDs(), InitialTimes: requests.Times()},
 				true, requests.Messages()...,
@@ -48,6 +52,8 @@ func (t *TxrV2) retrieveBatch(ctx context.Context) types.Requests {
 	for {
 		select {
 		case <-ctx.Done():
+			// Return any retrieved requests to the queue, to be picked up without delay.
+			t.releaseRequests(context.Background(), requests.MsgIDs()...)
 			return nil
 		case <-timer.C:
 			return requests
@@ -64,10 +70,11 @@ func (t *TxrV2) retrieveBatch(ctx context.Context) types.Requests {
 			}
 
 			// Get at most txsRemaining tx requests from the queue.
-			msgIDs, txReqs, err := t.requests.ReceiveMany(int32(txsRemaining))
-			if err != nil {
+			// Any tx requests received alongside an error are still processed.
+			msgIDs, txReqs, err := t.requests.ReceiveMany(ctx, int32(txsRemaining))
+			if err != nil && ctx.Err() == nil {
 				t.logger.Error("failed to receive tx request", "err", err)
-				continue
+				t.metrics.IncMonotonic("transactor.queue.receive_errors", nil)
 			}
 
 			// If using the queue message ID, we need to update the message ID for each tx request.
@@ -77,12 +84,104 @@ func (t *TxrV2) retrieveBatch(ctx context.Context) types.Requests {
 				}
 			}
 
-			// Append the tx requests for retrieval.
-			requests = append(requests, txReqs...)
+			// Append the tx requests for retrieval, skipping any redelivered by the queue while
+			// their tx is still being built, sent, or tracked.
+			for _, txReq := range txReqs {
+				if t.isInProcess(txReq.MsgID) {
+					t.logger.Warn("skipping redelivered tx request", "msgID", txReq.MsgID)
+					t.metrics.IncMonotonic("transactor.queue.redelivered", nil)
+					continue
+				}
+				requests = append(requests, txReq)
+			}
 		}
 	}
 }
 
+// defaultMaxRequestAttempts is the default max number of attempts of a failed tx request.
+const defaultMaxRequestAttempts = 3
+
+// batchDeleter is implemented by queues that can delete many messages in one request.
+type batchDeleter interface {
+	DeleteMany(ctx context.Context, messageIDs ...string) error
+}
+
+// releaseRequests returns the requests with the given message IDs to the queue early. Queues that
+// cannot release messages redeliver them after their visibility timeout instead.
+func (t *TxrV2) releaseRequests(ctx context.Context, msgIDs ...string) {
+	for _, msgID := range msgIDs {
+		err := t.requests.Release(ctx, msgID)
+		if err != nil && !errors.Is(err, queuetypes.ErrReleaseNotSupported) {
+			t.logger.Error("failed to release tx request", "msgID", msgID, "err", err)
+		}
+	}
+}
+
+// deleteRequests marks the requests with the given message IDs as processed on the queue, in
+// batches if supported by the queue.
+func (t *TxrV2) deleteRequests(ctx context.Context, msgIDs ...string) {
+	t.attemptsMu.Lock()
+	for _, msgID := range msgIDs {
+		delete(t.attempts, msgID)
+	}
+	t.attemptsMu.Unlock()
+
+	if deleter, ok := t.requests.(batchDeleter); ok {
+		if err := deleter.DeleteMany(ctx, msgIDs...); err != nil {
+			t.logger.Error("error deleting requests from queue", "ids", msgIDs, "err", err)
+		}
+		return
+	}
+
+	// Otherwise, mark the msgs as processed on the queue in parallel.
+	var errs sync.Map
+	var wg sync.WaitGroup
+	for _, id := range msgIDs {
+		wg.Add(1)
+		go func(_id string) {
+			defer wg.Done()
+			if err := t.requests.Delete(ctx, _id); err != nil {
+				errs.Store(_id, err)
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	// Log any errors that occurred during deletion.
+	errs.Range(func(key, value interface{}) bool {
+		t.logger.Error("error deleting request from queue", "id", key, "err", value)
+		return true
+	})
+}
+
+// retryRequests releases the failed requests with the given message IDs back to the queue to be
+// retried, or deletes those that reached the max attempts.
+func (t *TxrV2) retryRequests(ctx context.Context, msgIDs ...string) {
+	maxAttempts := t.cfg.MaxRequestAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxRequestAttempts
+	}
+
+	var retry, drop []string
+	t.attemptsMu.Lock()
+	for _, msgID := range msgIDs {
+		t.attempts[msgID]++
+		if t.attempts[msgID] < maxAttempts {
+			retry = append(retry, msgID)
+		} else {
+			drop = append(drop, msgID)
+		}
+	}
+	t.attemptsMu.Unlock()
+
+	t.releaseRequests(ctx, retry...)
+	if len(drop) > 0 {
+		t.logger.Error("dropping tx requests that reached the max attempts", "msgs", drop)
+		t.metrics.Count("transactor.requests.dropped", int64(len(drop)), nil)
+		t.deleteRequests(ctx, drop...)
+	}
+}
+
 // fire processes the tracked tx response. If requested to build, it will first batch the messages.
 // Then it sends the batch as one tx and asynchronously tracks the tx for its status. Will return
 // early and notify tx subscribers if an error occurs during building or sending.
@@ -97,7 +196,9 @@ func (t *TxrV2) fire(
 	if toBuild {
 		// Call the factory to build the (batched) transaction.
 		t.markState(types.StateBuilding, resp.MsgIDs...)
+		start := time.Now()
 		resp.Transaction, resp.Error = t.factory.BuildTransactionFromRequests(ctx, msgs...)
+		t.metrics.Time("transactor.build.duration", time.Since(start), nil)
 		if resp.Error != nil {
 			t.dispatcher.Dispatch(resp)
 			return
@@ -106,7 +207,10 @@ func (t *TxrV2) fire(
 
 	// Call the sender to send the transaction to the chain.
 	t.markState(types.StateSending, resp.MsgIDs...)
-	if resp.Error = t.sender.SendTransaction(ctx, resp.Transaction); resp.Error != nil {
+	start := time.Now()
+	resp.Error = t.sender.SendTransaction(ctx, resp.Transaction)
+	t.metrics.Time("transactor.send.duration", time.Since(start), nil)
+	if resp.Error != nil {
 		t.dispatcher.Dispatch(resp)
 		return
 	}
@@ -0,0 +1,62 @@
+package notify
+
+import "time"
+
+const (
+	defaultWebhookTimeout      = 5 * time.Second
+	defaultWebhookMaxRetries   = 3
+	defaultWebhookRetryBackoff = 500 * time.Millisecond
+	defaultWebhookBufferSize   = 1024
+)
+
+// SSEConfig is the configuration for streaming tx outcomes as Server-Sent Events.
+type SSEConfig struct {
+	// Path to serve the stream at, on the app's built-in HTTP server. If empty, outcomes are not
+	// streamed.
+	Path string
+}
+
+// Enabled returns true if a path to serve the stream at is configured.
+func (c SSEConfig) Enabled() bool {
+	return c.Path != ""
+}
+
+// WebhookConfig is the configuration for POSTing tx outcomes to webhooks.
+type WebhookConfig struct {
+	// URLs to POST every tx outcome to. If empty, no webhooks are sent.
+	URLs []string
+	// Secret used to sign the payloads with HMAC-SHA256. The hex-encoded signature is sent in the
+	// `X-Signature-256` header as "sha256=<signature>". If empty, payloads are not signed.
+	Secret string
+	// How long to wait for a single webhook request to complete.
+	Timeout time.Duration
+	// How many times a failed webhook request is retried (with exponential backoff).
+	MaxRetries int
+	// How long to wait before the first retry; doubled after every attempt.
+	RetryBackoff time.Duration
+	// How many outcomes can be buffered while webhooks are being sent. If full, the oldest
+	// outcome is dropped.
+	BufferSize int
+}
+
+// Enabled returns true if at least one webhook URL is configured.
+func (c WebhookConfig) Enabled() bool {
+	return len(c.URLs) > 0
+}
+
+// withDefaults returns the config with defaults filled in for any unset fields.
+func (c WebhookConfig) withDefaults() WebhookConfig {
+	if c.Timeout == 0 {
+		c.Timeout = defaultWebhookTimeout
+	}
+	if c.MaxRetries == 0 {
+		c.MaxRetries = defaultWebhookMaxRetries
+	}
+	if c.RetryBackoff == 0 {
+		c.RetryBackoff = defaultWebhookRetryBackoff
+	}
+	if c.BufferSize == 0 {
+		c.BufferSize = defaultWebhookBufferSize
+	}
+	return c
+}
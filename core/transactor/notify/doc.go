@@ -0,0 +1,3 @@
+// package notify provides tx result subscribers that deliver tx outcomes to external services,
+// via signed webhooks or a Server-Sent Events stream.
+package notify
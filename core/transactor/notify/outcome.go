@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"strings"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Outcome is the JSON payload describing the outcome of a tx sent by the transactor.
+type Outcome struct {
+	MsgIDs      []string  `json:"msgIDs"`
+	Status      string    `json:"status"`
+	TxHash      string    `json:"txHash,omitempty"`
+	Nonce       uint64    `json:"nonce"`
+	BlockNumber uint64    `json:"blockNumber,omitempty"`
+	GasUsed     uint64    `json:"gasUsed,omitempty"`
+	Error       string    `json:"error,omitempty"`
+	Timestamp   time.Time `json:"timestamp"`
+}
+
+// NewOutcome builds the outcome for the given tx response. The receipt is only provided if the tx
+// has been included in a block.
+func NewOutcome(resp *tracker.Response, receipt *coretypes.Receipt) *Outcome {
+	o := &Outcome{
+		MsgIDs:    resp.MsgIDs,
+		Status:    resp.Status().String(),
+		Nonce:     resp.Nonce(),
+		Timestamp: time.Now().UTC(),
+	}
+	if resp.Transaction != nil {
+		o.TxHash = resp.Hash().Hex()
+	}
+	if resp.Error != nil {
+		o.Error = resp.Error.Error()
+	}
+	if receipt != nil {
+		if receipt.BlockNumber != nil {
+			o.BlockNumber = receipt.BlockNumber.Uint64()
+		}
+		o.GasUsed = receipt.GasUsed
+	}
+	return o
+}
+
+// HasMsgIDPrefix returns true if any of the outcome's message IDs start with the given prefix. An
+// empty prefix matches every outcome.
+func (o *Outcome) HasMsgIDPrefix(prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	for _, msgID := range o.MsgIDs {
+		if strings.HasPrefix(msgID, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,166 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/event"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/log"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// MsgIDPrefixParam is the query parameter used to filter streamed outcomes by MsgID prefix.
+	MsgIDPrefixParam = "msgIDPrefix"
+
+	sseClientBufferSize = 64
+	sseKeepAlive        = 15 * time.Second
+)
+
+var (
+	_ tracker.Subscriber = (*SSEBroker)(nil)
+	_ http.Handler       = (*SSEBroker)(nil)
+)
+
+// SSEBroker is a tx results subscriber that streams outcomes to HTTP clients as Server-Sent
+// Events. Clients may filter the outcomes by MsgID prefix with the `msgIDPrefix` query parameter.
+// Outcomes are dropped for clients that do not keep up.
+type SSEBroker struct {
+	logger log.Logger
+
+	mu      sync.RWMutex
+	nextID  uint64
+	clients map[uint64]*sseClient
+}
+
+// sseClient is a single connected stream.
+type sseClient struct {
+	prefix   string
+	outcomes chan *Outcome
+}
+
+// NewSSEBroker creates a new SSE broker with no connected clients.
+func NewSSEBroker() *SSEBroker {
+	return &SSEBroker{clients: make(map[uint64]*sseClient)}
+}
+
+// SetLogger sets the logger used by the broker. Must be called before subscribing the broker.
+func (b *SSEBroker) SetLogger(logger log.Logger) {
+	b.logger = logger
+}
+
+// SubscriberConfig returns the config to subscribe the broker to tx results with, such that slow
+// clients never block the transactor.
+func (b *SSEBroker) SubscriberConfig() event.SubscriberConfig {
+	return event.SubscriberConfig{
+		BufferSize: sseClientBufferSize, Overflow: event.OverflowDropOldest,
+	}
+}
+
+// OnError implements tracker.Subscriber.
+func (b *SSEBroker) OnError(_ context.Context, resp *tracker.Response) {
+	b.broadcast(NewOutcome(resp, nil))
+}
+
+// OnSuccess implements tracker.Subscriber.
+func (b *SSEBroker) OnSuccess(resp *tracker.Response, receipt *coretypes.Receipt) {
+	b.broadcast(NewOutcome(resp, receipt))
+}
+
+// OnRevert implements tracker.Subscriber.
+func (b *SSEBroker) OnRevert(resp *tracker.Response, receipt *coretypes.Receipt) {
+	b.broadcast(NewOutcome(resp, receipt))
+}
+
+// OnStale implements tracker.Subscriber.
+func (b *SSEBroker) OnStale(_ context.Context, resp *tracker.Response, _ bool) {
+	b.broadcast(NewOutcome(resp, nil))
+}
+
+// broadcast sends the outcome to every client whose filter matches, without blocking.
+func (b *SSEBroker) broadcast(outcome *Outcome) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, client := range b.clients {
+		if !outcome.HasMsgIDPrefix(client.prefix) {
+			continue
+		}
+		select {
+		case client.outcomes <- outcome:
+		default:
+			b.logger.Warn("dropping tx outcome for slow SSE client", "msgs", outcome.MsgIDs)
+		}
+	}
+}
+
+// ServeHTTP implements http.Handler, streaming outcomes to the client until it disconnects.
+func (b *SSEBroker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	id, client := b.addClient(r.URL.Query().Get(MsgIDPrefixParam))
+	defer b.removeClient(id)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(sseKeepAlive)
+	defer keepAlive.Stop()
+
+	var eventID uint64
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		case outcome := <-client.outcomes:
+			data, err := json.Marshal(outcome)
+			if err != nil {
+				b.logger.Error("failed to marshal tx outcome for SSE", "err", err)
+				continue
+			}
+			eventID++
+			if _, err = fmt.Fprintf(
+				w, "id: %d\nevent: %s\ndata: %s\n\n", eventID, outcome.Status, data,
+			); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// addClient registers a new client with the given MsgID prefix filter.
+func (b *SSEBroker) addClient(prefix string) (uint64, *sseClient) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	client := &sseClient{prefix: prefix, outcomes: make(chan *Outcome, sseClientBufferSize)}
+	b.clients[b.nextID] = client
+	return b.nextID, client
+}
+
+// removeClient unregisters the client with the given ID.
+func (b *SSEBroker) removeClient(id uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.clients, id)
+}
@@ -0,0 +1,81 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/core/transactor/notify"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/stretchr/testify/require"
+)
+
+// readEvent reads the next Server-Sent Event from the stream, returning its fields by name.
+func readEvent(t *testing.T, r *bufio.Reader) map[string]string {
+	t.Helper()
+	fields := make(map[string]string)
+	for {
+		line, err := r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return fields
+		}
+		name, value, _ := strings.Cut(line, ": ")
+		fields[name] = value
+	}
+}
+
+// connect connects a client to the SSE stream, with the given MsgID prefix filter.
+func connect(t *testing.T, url, prefix string) *bufio.Reader {
+	t.Helper()
+	req, err := http.NewRequestWithContext(
+		context.Background(), http.MethodGet, url+"?"+notify.MsgIDPrefixParam+"="+prefix, nil,
+	)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { resp.Body.Close() })
+	require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+	return bufio.NewReader(resp.Body)
+}
+
+func TestSSEBrokerStreamsOutcomes(t *testing.T) {
+	broker := notify.NewSSEBroker()
+	broker.SetLogger(log.NewLogger(os.Stdout, "test-runner"))
+	srv := httptest.NewServer(broker)
+	t.Cleanup(srv.Close) // After the clients disconnect.
+
+	all := connect(t, srv.URL, "")
+	swaps := connect(t, srv.URL, "swap-")
+
+	broker.OnError(context.Background(),
+		&tracker.Response{MsgIDs: []string{"order-1"}, Error: errors.New("boom")})
+	broker.OnError(context.Background(),
+		&tracker.Response{MsgIDs: []string{"swap-2"}, Error: errors.New("bust")})
+
+	// Every outcome is framed as an event, named after its status.
+	for i, msgID := range []string{"order-1", "swap-2"} {
+		event := readEvent(t, all)
+		require.Equal(t, []string{"1", "2"}[i], event["id"])
+		require.Equal(t, tracker.StatusError.String(), event["event"])
+		var outcome notify.Outcome
+		require.NoError(t, json.Unmarshal([]byte(event["data"]), &outcome))
+		require.Equal(t, []string{msgID}, outcome.MsgIDs)
+	}
+
+	// Filtered clients only receive the outcomes with a matching MsgID.
+	event := readEvent(t, swaps)
+	require.Equal(t, "1", event["id"])
+	var outcome notify.Outcome
+	require.NoError(t, json.Unmarshal([]byte(event["data"]), &outcome))
+	require.Equal(t, []string{"swap-2"}, outcome.MsgIDs)
+	require.Equal(t, "bust", outcome.Error)
+}
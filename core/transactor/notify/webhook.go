@@ -0,0 +1,167 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/event"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// SignatureHeader is the HTTP header containing the HMAC-SHA256 signature of the payload.
+const SignatureHeader = "X-Signature-256"
+
+var (
+	_ tracker.Subscriber = (*Webhook)(nil)
+	_ io.Closer          = (*Webhook)(nil)
+)
+
+// Webhook is a tx results subscriber that POSTs signed JSON outcomes to the configured URLs.
+type Webhook struct {
+	cfg     WebhookConfig
+	client  *http.Client
+	logger  log.Logger
+	metrics telemetry.Metrics
+
+	// ctx is cancelled when the webhook is closed, which aborts the deliveries in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewWebhook creates a new webhook subscriber with the given config.
+func NewWebhook(cfg WebhookConfig, logger log.Logger, metrics telemetry.Metrics) *Webhook {
+	cfg = cfg.withDefaults()
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Webhook{
+		cfg:     cfg,
+		client:  &http.Client{Timeout: cfg.Timeout},
+		logger:  logger,
+		metrics: metrics,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Close implements io.Closer, aborting the deliveries in progress, including their retries.
+func (w *Webhook) Close() error {
+	w.cancel()
+	return nil
+}
+
+// SubscriberConfig returns the config to subscribe the webhook to tx results with, such that a
+// slow webhook endpoint never blocks the transactor.
+func (w *Webhook) SubscriberConfig() event.SubscriberConfig {
+	return event.SubscriberConfig{BufferSize: w.cfg.BufferSize, Overflow: event.OverflowDropOldest}
+}
+
+// OnError implements tracker.Subscriber.
+func (w *Webhook) OnError(ctx context.Context, resp *tracker.Response) {
+	w.send(ctx, NewOutcome(resp, nil))
+}
+
+// OnSuccess implements tracker.Subscriber.
+func (w *Webhook) OnSuccess(resp *tracker.Response, receipt *coretypes.Receipt) {
+	w.send(w.ctx, NewOutcome(resp, receipt))
+}
+
+// OnRevert implements tracker.Subscriber.
+func (w *Webhook) OnRevert(resp *tracker.Response, receipt *coretypes.Receipt) {
+	w.send(w.ctx, NewOutcome(resp, receipt))
+}
+
+// OnStale implements tracker.Subscriber.
+func (w *Webhook) OnStale(ctx context.Context, resp *tracker.Response, _ bool) {
+	w.send(ctx, NewOutcome(resp, nil))
+}
+
+// send POSTs the outcome to every configured URL, retrying each according to the config.
+func (w *Webhook) send(ctx context.Context, outcome *Outcome) {
+	body, err := json.Marshal(outcome)
+	if err != nil {
+		w.logger.Error("failed to marshal tx outcome for webhook", "err", err)
+		return
+	}
+	signature := w.sign(body)
+
+	for _, url := range w.cfg.URLs {
+		if err = w.postWithRetry(ctx, url, body, signature); err != nil {
+			w.metrics.IncMonotonic("transactor.webhook.failures", nil)
+			w.logger.Error(
+				"failed to deliver tx outcome to webhook", "url", url, "msgs", outcome.MsgIDs,
+				"err", err,
+			)
+			continue
+		}
+		w.metrics.IncMonotonic("transactor.webhook.delivered", nil)
+	}
+}
+
+// postWithRetry POSTs the body to the url, retrying with exponential backoff on failure.
+func (w *Webhook) postWithRetry(ctx context.Context, url string, body []byte, sig string) error {
+	backoff := w.cfg.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := w.post(ctx, url, body, sig)
+		if err == nil || attempt >= w.cfg.MaxRetries {
+			return err
+		}
+
+		w.metrics.IncMonotonic("transactor.webhook.retries", nil)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+			backoff *= 2
+		}
+	}
+}
+
+// post sends a single POST request of the body to the url.
+func (w *Webhook) post(ctx context.Context, url string, body []byte, sig string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if sig != "" {
+		req.Header.Set(SignatureHeader, sig)
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sign returns the HMAC-SHA256 signature of the body, or empty if no secret is configured.
+func (w *Webhook) sign(body []byte) string {
+	if w.cfg.Secret == "" {
+		return ""
+	}
+	return Sign(w.cfg.Secret, body)
+}
+
+// Sign returns the value of the signature header for the given payload, signed with the secret.
+// Receivers may use this to verify that a payload was sent by the transactor.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,71 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/notify"
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookSignsAndRetries(t *testing.T) {
+	const secret = "shh"
+	var (
+		attempts atomic.Int32
+		received = make(chan *notify.Outcome, 1)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Fail the first attempt to exercise the retry.
+		if attempts.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.Equal(t, notify.Sign(secret, body), r.Header.Get(notify.SignatureHeader))
+
+		var outcome notify.Outcome
+		require.NoError(t, json.Unmarshal(body, &outcome))
+		received <- &outcome
+	}))
+	defer srv.Close()
+
+	metrics, err := telemetry.NewMetrics(&telemetry.Config{})
+	require.NoError(t, err)
+	webhook := notify.NewWebhook(notify.WebhookConfig{
+		URLs:         []string{srv.URL},
+		Secret:       secret,
+		RetryBackoff: time.Millisecond,
+	}, log.NewLogger(os.Stdout, "test-runner"), metrics)
+
+	webhook.OnError(
+		context.Background(),
+		&tracker.Response{MsgIDs: []string{"a", "b"}, Error: errors.New("boom")},
+	)
+
+	outcome := <-received
+	require.Equal(t, []string{"a", "b"}, outcome.MsgIDs)
+	require.Equal(t, tracker.StatusError.String(), outcome.Status)
+	require.Equal(t, "boom", outcome.Error)
+	require.Equal(t, int32(2), attempts.Load())
+}
+
+func TestOutcomeMsgIDPrefix(t *testing.T) {
+	outcome := notify.NewOutcome(&tracker.Response{MsgIDs: []string{"order-1", "swap-2"}}, nil)
+
+	require.True(t, outcome.HasMsgIDPrefix(""))
+	require.True(t, outcome.HasMsgIDPrefix("swap-"))
+	require.False(t, outcome.HasMsgIDPrefix("mint-"))
+}
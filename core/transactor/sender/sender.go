@@ -7,6 +7,7 @@ import (
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
 
 	coretypes "github.com/ethereum/go-ethereum/core/types"
 )
@@ -16,17 +17,19 @@ type Sender struct {
 	factory             Factory             // used to rebuild transactions, if necessary
 	txReplacementPolicy txReplacementPolicy // policy to replace transactions
 	retryPolicy         retryPolicy         // policy to retry transactions
+	metrics             telemetry.Metrics   // records retries and replacements
 
 	chain  eth.Client
 	logger log.Logger
 }
 
 // New creates a new Sender with default replacement and exponential retry policies.
-func New(factory Factory, noncer Noncer) *Sender {
+func New(factory Factory, noncer Noncer, metrics telemetry.Metrics) *Sender {
 	return &Sender{
 		factory:             factory,
 		txReplacementPolicy: &defaultTxReplacementPolicy{noncer: noncer},
 		retryPolicy:         &expoRetryPolicy{}, // TODO: choose from config.
+		metrics:             metrics,
 	}
 }
 
@@ -54,6 +57,7 @@ func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transactio
 		if !retry {
 			return err
 		}
+		s.metrics.IncMonotonic("transactor.send.retries", nil)
 		time.Sleep(backoff) // Retry after recommended backoff.
 
 		// Log relevant details about retrying the transaction.
@@ -74,6 +78,7 @@ func (s *Sender) retryTxWithPolicy(ctx context.Context, tx *coretypes.Transactio
 				"old-nonce", currNonce, "new-nonce", tx.Nonce(),
 			)
 			s.retryPolicy.UpdateTxModified(currTx, newTx)
+			s.metrics.IncMonotonic("transactor.tx.replacements", []string{"reason:send_retry"})
 		}
 
 		// Use the factory to build and sign the new transaction.
@@ -2,62 +2,51 @@ package transactor
 
 import (
 	"context"
-	"sync"
+	"math/big"
+	"time"
 
 	"github.com/berachain/offchain-sdk/core/transactor/sender"
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 
 	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
 )
 
-// OnError is called when a transaction request fails to build or send.
-func (t *TxrV2) OnError(_ context.Context, resp *tracker.Response) {
+// OnError is called when a transaction request fails to build or send. The msgs are released back
+// to the queue to be retried, until they reach the max attempts.
+func (t *TxrV2) OnError(ctx context.Context, resp *tracker.Response) {
 	t.noncer.RemoveAcquired(resp.Nonce())
 	t.removeStateTracking(resp.MsgIDs...)
+	t.recordOutcome(resp, nil)
 	t.logger.Error("❌ error sending transaction", "err", resp.Error, "msgs", resp.MsgIDs)
 
-	// TODO: move ontop dead queue, for SQS.
+	t.retryRequests(ctx, resp.MsgIDs...)
 }
 
 // OnSuccess is called when a transaction has been successfully included in a block.
 func (t *TxrV2) OnSuccess(resp *tracker.Response, receipt *coretypes.Receipt) {
 	t.removeStateTracking(resp.MsgIDs...)
+	t.recordOutcome(resp, receipt)
 	t.logger.Info(
 		"⛏️ transaction mined: success", "tx-hash", receipt.TxHash.Hex(),
 		"gas-used", receipt.GasUsed, "status", receipt.Status, "nonce", resp.Nonce(),
 	)
 
-	// Mark the msgs as processed on the queue in parallel.
-	var errs sync.Map
-	var wg sync.WaitGroup
-	for _, id := range resp.MsgIDs {
-		wg.Add(1)
-		go func(_id string) {
-			defer wg.Done()
-			if err := t.requests.Delete(_id); err != nil {
-				errs.Store(_id, err)
-			}
-		}(id)
-	}
-	wg.Wait()
-
-	// Log any errors that occurred during deletion.
-	errs.Range(func(key, value interface{}) bool {
-		t.logger.Error("error deleting request from queue", "id", key, "err", value)
-		return true
-	})
+	t.deleteRequests(context.Background(), resp.MsgIDs...)
 }
 
 // OnRevert is called when a transaction has been reverted.
 func (t *TxrV2) OnRevert(resp *tracker.Response, receipt *coretypes.Receipt) {
 	t.removeStateTracking(resp.MsgIDs...)
+	t.recordOutcome(resp, receipt)
 	t.logger.Warn(
 		"🔻 transaction mined: reverted", "tx-hash", receipt.TxHash.Hex(),
 		"gas-used", receipt.GasUsed, "status", receipt.Status, "nonce", resp.Nonce(),
 	)
 
-	// TODO: delete from SQS queue / move onto the dead queue?
+	// The msgs were executed, so they are not retried.
+	t.deleteRequests(context.Background(), resp.MsgIDs...)
 }
 
 // OnStale is called when a transaction becomes stale after the configured timeout.
@@ -72,10 +61,48 @@ func (t *TxrV2) OnStale(ctx context.Context, resp *tracker.Response, isPending b
 		// For a tx that gets stuck in the mempool as pending, it can only be included in a block
 		// by bumping gas. Resend it (same tx data, same nonce) with a bumped gas.
 		resp.Transaction = sender.BumpGas(resp.Transaction)
+		t.metrics.IncMonotonic("transactor.tx.replacements", []string{"reason:stale_pending"})
 		go t.fire(ctx, resp, false)
 	} else if t.cfg.ResendStaleTxs {
 		// Try resending the tx to the chain if configured to do so. Rebuild it (same tx data, new
 		// nonce) and resend.
+		t.metrics.IncMonotonic("transactor.tx.replacements", []string{"reason:stale_rebuild"})
 		go t.fire(ctx, resp, true, types.CallMsgFromTx(resp.Transaction))
+	} else {
+		// Otherwise, the msgs are released back to the queue to be retried. The outcome is only
+		// recorded here, as resent txs record their own.
+		t.recordOutcome(resp, nil)
+		t.retryRequests(ctx, resp.MsgIDs...)
+	}
+}
+
+// recordOutcome records the metrics for a tx whose final status has been determined (i.e. it is not
+// resent). The receipt is only provided if the tx has been included in a block.
+func (t *TxrV2) recordOutcome(resp *tracker.Response, receipt *coretypes.Receipt) {
+	tags := []string{"status:" + resp.Status().String()}
+	t.metrics.IncMonotonic("transactor.tx.outcome", tags)
+	t.metrics.Count("transactor.requests.outcome", int64(len(resp.MsgIDs)), tags)
+	if receipt == nil {
+		return
+	}
+
+	// Record the time taken for each request to be included in a block.
+	for _, initialTime := range resp.InitialTimes {
+		t.metrics.Time("transactor.tx.inclusion_latency", time.Since(initialTime), tags)
+	}
+
+	// Record the gas used and the effective fee (in fractional gwei, as gas prices can be below
+	// 1 gwei) paid for the tx. The fee is a histogram, whose sum is the total fee paid.
+	t.metrics.Gauge("transactor.tx.gas_used", float64(receipt.GasUsed), tags, 1)
+	t.metrics.Count("transactor.tx.gas_used_total", int64(receipt.GasUsed), tags)
+	if receipt.EffectiveGasPrice != nil {
+		gasPriceGwei := new(big.Float).Quo(
+			new(big.Float).SetInt(receipt.EffectiveGasPrice), big.NewFloat(params.GWei),
+		)
+		feeGwei := new(big.Float).Mul(gasPriceGwei, new(big.Float).SetUint64(receipt.GasUsed))
+		gasPrice, _ := gasPriceGwei.Float64()
+		fee, _ := feeGwei.Float64()
+		t.metrics.Gauge("transactor.tx.effective_gas_price_gwei", gasPrice, tags, 1)
+		t.metrics.Histogram("transactor.tx.fee_gwei", fee, tags, 1)
 	}
 }
@@ -2,6 +2,8 @@ package tracker
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"strconv"
 	"sync"
 	"time"
@@ -30,6 +32,7 @@ type Noncer struct {
 
 	mu              sync.Mutex    // Mutex for thread-safe operations.
 	refreshInterval time.Duration // How often to refresh the mempool state.
+	refreshErr      error         // The error of the last refresh of the pending nonce, if any.
 }
 
 // NewNoncer creates a new Noncer instance.
@@ -68,11 +71,13 @@ func (n *Noncer) refreshNonces(ctx context.Context) {
 	n.mu.Lock()
 	defer n.mu.Unlock()
 
-	if pendingNonce, err := n.ethClient.PendingNonceAt(ctx, n.sender); err == nil {
+	pendingNonce, err := n.ethClient.PendingNonceAt(ctx, n.sender)
+	if err == nil {
 		// This should already be in sync with latest pending nonce according to the chain.
 		n.latestPendingNonce = pendingNonce
 		// TODO: handle case where stored & chain pending nonce is out of sync?
 	}
+	n.refreshErr = err
 
 	// Use txpool.inspect instead of txpool.content. Less data to fetch.
 	if content, err := n.ethClient.TxPoolInspect(ctx); err == nil {
@@ -155,9 +160,26 @@ func (n *Noncer) RemoveInFlight(nonce uint64) {
 
 // Stats returns the number of acquired nonces and the number of in-flight transactions.
 func (n *Noncer) Stats() (int, int) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
 	return len(n.acquired), n.inFlight.Len()
 }
 
+// CheckHealth implements health.Checker, failing if the last refresh of the pending nonce failed.
+func (n *Noncer) CheckHealth(context.Context) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.ethClient == nil {
+		return errors.New("noncer not started")
+	}
+	if n.refreshErr != nil {
+		return fmt.Errorf("failed to refresh pending nonce: %w", n.refreshErr)
+	}
+	return nil
+}
+
 // mustNonce returns the nonce of an element from the key.
 func mustNonce(element *skiplist.Element) uint64 {
 	return utils.MustGetAs[uint64](element.Key())
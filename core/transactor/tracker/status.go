@@ -11,3 +11,21 @@ const (
 	StatusReverted
 	StatusStale
 )
+
+// String implements fmt.Stringer.
+func (s Status) String() string {
+	switch s {
+	case StatusPending:
+		return "pending"
+	case StatusError:
+		return "error"
+	case StatusSuccess:
+		return "success"
+	case StatusReverted:
+		return "reverted"
+	case StatusStale:
+		return "stale"
+	default:
+		return "unknown"
+	}
+}
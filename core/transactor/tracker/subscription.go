@@ -30,17 +30,22 @@ func NewSubscription(s Subscriber, logger log.Logger) *Subscription {
 	return &Subscription{Subscriber: s, logger: logger}
 }
 
-// Start starts the Subscription, listening for transaction events.
-//
-
-func (sub *Subscription) Start(ctx context.Context, ch chan *Response) {
+// Start starts the Subscription, listening for transaction events until the context is done or
+// the channel is closed (i.e. the subscriber was unsubscribed or disconnected).
+func (sub *Subscription) Start(ctx context.Context, ch <-chan *Response) {
 	// Loop over the channel, handling events as they come in.
 	for {
 		select {
 		case <-ctx.Done():
 			// If the context is done, return to stop the loop.
 			return
-		case e := <-ch:
+		case e, ok := <-ch:
+			if !ok {
+				// If the channel is closed, no more events will be sent.
+				sub.logger.Warn("tx results subscription closed")
+				return
+			}
+
 			// Handle the response based on its Status.
 			switch e.Status() {
 			case StatusError:
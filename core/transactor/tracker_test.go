@@ -0,0 +1,128 @@
+package transactor
+
+import (
+	"context"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/core/transactor/tracker"
+	"github.com/berachain/offchain-sdk/core/transactor/types"
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+	queuetypes "github.com/berachain/offchain-sdk/types/queue/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// recordedMetrics records the values of the gauges and histograms and the totals of the counts,
+// ignoring the other metrics.
+type recordedMetrics struct {
+	telemetry.Metrics
+	values map[string]float64
+}
+
+func (m *recordedMetrics) Gauge(name string, value float64, _ []string, _ float64) {
+	m.values[name] = value
+}
+
+func (m *recordedMetrics) Histogram(name string, value float64, _ []string, _ float64) {
+	m.values[name] = value
+}
+
+func (*recordedMetrics) IncMonotonic(string, []string) {}
+
+func (m *recordedMetrics) Count(name string, value int64, _ []string) {
+	m.values[name] += float64(value)
+}
+
+func (*recordedMetrics) Time(string, time.Duration, []string) {}
+
+// settledQueue is a tx request queue that records the msgs deleted and released.
+type settledQueue struct {
+	queuetypes.QueueV2[*types.Request]
+	mu                sync.Mutex
+	deleted, released []string
+}
+
+func (q *settledQueue) Delete(_ context.Context, msgID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.deleted = append(q.deleted, msgID)
+	return nil
+}
+
+func (q *settledQueue) Release(_ context.Context, msgID string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.released = append(q.released, msgID)
+	return nil
+}
+
+// newSettlingTransactor returns a transactor whose queue records how tx outcomes are settled.
+func newSettlingTransactor(cfg Config) (*TxrV2, *settledQueue) {
+	queue := &settledQueue{}
+	return &TxrV2{
+		cfg:                cfg,
+		logger:             log.NewBlankLogger(io.Discard),
+		metrics:            &recordedMetrics{values: make(map[string]float64)},
+		requests:           queue,
+		noncer:             tracker.NewNoncer(common.Address{}, time.Second),
+		preconfirmedStates: make(map[string]types.PreconfirmedState),
+		attempts:           make(map[string]int),
+	}, queue
+}
+
+func TestRevertedRequestsAreDeleted(t *testing.T) {
+	txr, queue := newSettlingTransactor(Config{})
+	txr.OnRevert(&tracker.Response{
+		Transaction: coretypes.NewTx(&coretypes.LegacyTx{}), MsgIDs: []string{"a", "b"},
+	}, &coretypes.Receipt{})
+	require.ElementsMatch(t, []string{"a", "b"}, queue.deleted)
+	require.Empty(t, queue.released)
+}
+
+func TestFailedRequestsAreRetriedUpToMaxAttempts(t *testing.T) {
+	ctx := context.Background()
+	txr, queue := newSettlingTransactor(Config{MaxRequestAttempts: 2})
+	resp := &tracker.Response{
+		Transaction: coretypes.NewTx(&coretypes.LegacyTx{}), MsgIDs: []string{"a"},
+	}
+
+	// The first failure releases the msg to be retried, the second drops it.
+	txr.OnError(ctx, resp)
+	require.Equal(t, []string{"a"}, queue.released)
+	require.Empty(t, queue.deleted)
+	txr.OnError(ctx, resp)
+	require.Equal(t, []string{"a"}, queue.released)
+	require.Equal(t, []string{"a"}, queue.deleted)
+}
+
+func TestStaleRequestsAreRetried(t *testing.T) {
+	txr, queue := newSettlingTransactor(Config{})
+	txr.OnStale(context.Background(), &tracker.Response{
+		Transaction: coretypes.NewTx(&coretypes.LegacyTx{}), MsgIDs: []string{"a"},
+	}, false)
+	require.Equal(t, []string{"a"}, queue.released)
+	require.Empty(t, queue.deleted)
+
+	// The outcome of the stale tx is recorded, as it is not resent.
+	require.InDelta(t, 1, txr.metrics.(*recordedMetrics).values["transactor.requests.outcome"], 0)
+}
+
+func TestRecordOutcomeSubGweiGasPrice(t *testing.T) {
+	metrics := &recordedMetrics{values: make(map[string]float64)}
+	txr := &TxrV2{metrics: metrics}
+
+	// A gas price of 0.25 gwei is not truncated to 0.
+	txr.recordOutcome(&tracker.Response{}, &coretypes.Receipt{
+		GasUsed:           21000,
+		EffectiveGasPrice: big.NewInt(250_000_000),
+	})
+	require.InDelta(t, 0.25, metrics.values["transactor.tx.effective_gas_price_gwei"], 1e-9)
+	require.InDelta(t, 5250, metrics.values["transactor.tx.fee_gwei"], 1e-9)
+}
@@ -3,19 +3,26 @@ package transactor
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"sync"
 	"time"
 
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/core/transactor/event"
 	"github.com/berachain/offchain-sdk/core/transactor/factory"
+	"github.com/berachain/offchain-sdk/core/transactor/notify"
 	"github.com/berachain/offchain-sdk/core/transactor/sender"
 	"github.com/berachain/offchain-sdk/core/transactor/tracker"
 	"github.com/berachain/offchain-sdk/core/transactor/types"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
 	sdk "github.com/berachain/offchain-sdk/types"
 	kmstypes "github.com/berachain/offchain-sdk/types/kms/types"
+	"github.com/berachain/offchain-sdk/types/queue/db"
 	"github.com/berachain/offchain-sdk/types/queue/mem"
+	"github.com/berachain/offchain-sdk/types/queue/redis"
 	"github.com/berachain/offchain-sdk/types/queue/sqs"
 	queuetypes "github.com/berachain/offchain-sdk/types/queue/types"
 
@@ -26,32 +33,61 @@ import (
 type TxrV2 struct {
 	cfg        Config
 	logger     log.Logger
+	metrics    telemetry.Metrics
 	signerAddr common.Address
 
-	requests     queuetypes.Queue[*types.Request]
+	requests     queuetypes.QueueV2[*types.Request]
 	factory      *factory.Factory
 	noncer       *tracker.Noncer
 	sender       *sender.Sender
 	senderMu     sync.Mutex
 	dispatcher   *event.Dispatcher[*tracker.Response]
 	tracker      *tracker.Tracker
-	trackerIndex int
+	trackerSubID event.SubscriptionID
+
+	// subscribers that deliver tx outcomes to external services
+	sse          *notify.SSEBroker
+	webhook      *notify.Webhook
+	notifySubIDs []event.SubscriptionID
 
 	preconfirmedStates map[string]types.PreconfirmedState
 	preconfirmedMu     sync.RWMutex
+
+	// attempts counts the failed attempts of the tx requests, by message ID.
+	attempts   map[string]int
+	attemptsMu sync.Mutex
 }
 
-// NewTransactor creates a new transactor with the given config and signer.
-func NewTransactor(cfg Config, signer kmstypes.TxSigner, batcher factory.Batcher) (*TxrV2, error) {
+// NewTransactor creates a new transactor with the given config and signer. Metrics for every stage
+// of the tx lifecycle are recorded to the given telemetry.Metrics; if nil, metrics are discarded.
+func NewTransactor(
+	cfg Config, signer kmstypes.TxSigner, batcher factory.Batcher, metrics telemetry.Metrics,
+) (*TxrV2, error) {
 	// Determine queue type based on given configuration.
-	var queue queuetypes.Queue[*types.Request]
-	if cfg.SQS.QueueURL != "" {
-		var err error
-		if queue, err = sqs.NewQueueFromConfig[*types.Request](cfg.SQS); err != nil {
+	var (
+		queue queuetypes.QueueV2[*types.Request]
+		err   error
+	)
+	switch {
+	case cfg.SQS.QueueURL != "":
+		if queue, err = sqs.NewQueueV2FromConfig[*types.Request](cfg.SQS); err != nil {
 			return nil, err
 		}
-	} else {
-		queue = mem.NewQueue[*types.Request]()
+	case cfg.Redis.Enabled():
+		redisQueue, rErr := redis.NewQueueFromConfig[*types.Request](cfg.Redis)
+		if rErr != nil {
+			return nil, rErr
+		}
+		queue = queuetypes.AdaptQueue[*types.Request](redisQueue)
+	case cfg.LocalQueue.Enabled():
+		dbQueue, dbErr := db.NewQueueFromConfig[*types.Request](cfg.LocalQueue)
+		if dbErr != nil {
+			return nil, dbErr
+		}
+		queue = queuetypes.AdaptQueue[*types.Request](dbQueue)
+	default:
+		// The in-memory queue consumes requests on receive, so that they are never redelivered.
+		queue = queuetypes.AdaptQueue[*types.Request](mem.NewQueue[*types.Request]())
 	}
 
 	// Ensure a batcher is provided if batching is required.
@@ -59,24 +95,34 @@ func NewTransactor(cfg Config, signer kmstypes.TxSigner, batcher factory.Batcher
 		return nil, errors.New("batcher must be provided when tx batch size is greater than 1")
 	}
 
+	// Use a metrics instance with no backends enabled if none is provided.
+	if metrics == nil {
+		if metrics, err = telemetry.NewMetrics(&telemetry.Config{}); err != nil {
+			return nil, err
+		}
+	}
+
 	// Build the transactor components.
 	noncer := tracker.NewNoncer(signer.Address(), cfg.PendingNonceInterval)
-	factory := factory.New(noncer, batcher, signer, cfg.SignTxTimeout)
-	dispatcher := event.NewDispatcher[*tracker.Response]()
+	factory := factory.New(noncer, batcher, signer, cfg.SignTxTimeout, metrics)
+	dispatcher := event.NewDispatcher[*tracker.Response](metrics)
 	tracker := tracker.New(
 		noncer, dispatcher, signer.Address(), cfg.InMempoolTimeout, cfg.TxReceiptTimeout,
 	)
 
 	return &TxrV2{
 		cfg:                cfg,
+		metrics:            metrics,
 		requests:           queue,
 		signerAddr:         signer.Address(),
 		factory:            factory,
 		noncer:             noncer,
-		sender:             sender.New(factory, noncer),
+		sender:             sender.New(factory, noncer, metrics),
 		dispatcher:         dispatcher,
 		tracker:            tracker,
+		sse:                notify.NewSSEBroker(),
 		preconfirmedStates: make(map[string]types.PreconfirmedState),
+		attempts:           make(map[string]int),
 	}, nil
 }
 
@@ -90,9 +136,25 @@ func (t *TxrV2) Setup(ctx context.Context) error {
 	sCtx := sdk.UnwrapContext(ctx)
 	chain := sCtx.Chain()
 	t.logger = sCtx.Logger()
+	// Report queue issues (e.g. SQS messages no longer heartbeated) with the transactor's logger.
+	if ls, ok := t.requests.(interface{ SetLogger(log.Logger) }); ok {
+		ls.SetLogger(t.logger)
+	}
 
 	// Register the transactor as a subscriber to the tracker.
-	t.trackerIndex = t.SubscribeTxResults(ctx, t)
+	t.trackerSubID = t.SubscribeTxResults(ctx, t)
+
+	// Register the subscribers that deliver tx outcomes to external services.
+	t.sse.SetLogger(t.logger)
+	t.notifySubIDs = append(
+		t.notifySubIDs, t.SubscribeTxResultsWithConfig(ctx, t.sse, t.sse.SubscriberConfig()),
+	)
+	if t.cfg.Webhooks.Enabled() {
+		t.webhook = notify.NewWebhook(t.cfg.Webhooks, t.logger, t.metrics)
+		t.notifySubIDs = append(t.notifySubIDs,
+			t.SubscribeTxResultsWithConfig(ctx, t.webhook, t.webhook.SubscriberConfig()),
+		)
+	}
 
 	// Setup and start all the transactor components.
 	t.factory.SetClient(chain)
@@ -111,12 +173,19 @@ func (t *TxrV2) Setup(ctx context.Context) error {
 }
 
 // Execute implements job.Basic.
-func (t *TxrV2) Execute(context.Context, any) (any, error) {
+func (t *TxrV2) Execute(ctx context.Context, _ any) (any, error) {
 	acquired, inFlight := t.noncer.Stats()
+	pending, err := t.requests.Len(ctx)
+	if err != nil {
+		t.logger.Error("failed to get tx queue length", "err", err)
+	}
 	t.logger.Info(
 		"🧠 system status",
-		"waiting-tx", acquired, "in-flight-tx", inFlight, "pending-requests", t.requests.Len(),
+		"waiting-tx", acquired, "in-flight-tx", inFlight, "pending-requests", pending,
 	)
+	t.metrics.Gauge("transactor.nonces.acquired", float64(acquired), nil, 1)
+	t.metrics.Gauge("transactor.nonces.in_flight", float64(inFlight), nil, 1)
+	t.metrics.Gauge("transactor.queue.depth", float64(pending), nil, 1)
 	return nil, nil //nolint:nilnil // its okay.
 }
 
@@ -127,26 +196,78 @@ func (t *TxrV2) IntervalTime(context.Context) time.Duration {
 
 // Teardown implements job.HasTeardown.
 func (t *TxrV2) Teardown() error {
-	t.dispatcher.Unsubscribe(t.trackerIndex)
+	t.dispatcher.Unsubscribe(t.trackerSubID)
+	for _, id := range t.notifySubIDs {
+		t.dispatcher.Unsubscribe(id)
+	}
+	if t.webhook != nil {
+		_ = t.webhook.Close()
+	}
+
+	// Release any resources held by the queue (e.g. the SQS visibility heartbeat).
+	if closer, ok := t.requests.(io.Closer); ok {
+		return closer.Close()
+	}
 	return nil
 }
 
+// CheckHealth implements health.Checker, failing if the tx queue is unreachable or the noncer can
+// not refresh the pending nonce.
+func (t *TxrV2) CheckHealth(ctx context.Context) error {
+	if _, err := t.requests.Len(ctx); err != nil {
+		return fmt.Errorf("tx queue unreachable: %w", err)
+	}
+	return t.noncer.CheckHealth(ctx)
+}
+
+// OutcomeStreamHandler returns a HTTP handler, to be registered on the built-in server at the
+// given path, that streams tx outcomes as Server-Sent Events. Clients may filter the outcomes by
+// MsgID prefix with the `msgIDPrefix` query parameter.
+func (t *TxrV2) OutcomeStreamHandler(path string) *server.Handler {
+	return &server.Handler{Path: path, Handler: t.sse}
+}
+
+// Handlers implements server.HasHandlers, serving the stream of tx outcomes at the configured
+// path, if enabled.
+func (t *TxrV2) Handlers() []*server.Handler {
+	if !t.cfg.OutcomeStream.Enabled() {
+		return nil
+	}
+	return []*server.Handler{t.OutcomeStreamHandler(t.cfg.OutcomeStream.Path)}
+}
+
 // SubscribeTxResults ensures that tx results, once confirmed, are sent the given subscriber. It
-// returns the global index of the subscription for the results.
-func (t *TxrV2) SubscribeTxResults(ctx context.Context, subscriber tracker.Subscriber) int {
-	ch := make(chan *tracker.Response)
+// uses the default subscriber config, which never drops results. Returns the unique ID of the
+// subscription for the results.
+func (t *TxrV2) SubscribeTxResults(
+	ctx context.Context, subscriber tracker.Subscriber,
+) event.SubscriptionID {
+	return t.SubscribeTxResultsWithConfig(ctx, subscriber, event.DefaultSubscriberConfig())
+}
+
+// SubscribeTxResultsWithConfig is the same as SubscribeTxResults, but allows configuring how
+// results are buffered for the subscriber and what happens if it falls behind.
+func (t *TxrV2) SubscribeTxResultsWithConfig(
+	ctx context.Context, subscriber tracker.Subscriber, cfg event.SubscriberConfig,
+) event.SubscriptionID {
+	id, ch := t.dispatcher.Subscribe(cfg)
 	go tracker.NewSubscription(subscriber, t.logger).Start(ctx, ch)
-	return t.dispatcher.Subscribe(ch)
+	return id
+}
+
+// UnsubscribeTxResults stops sending tx results to the subscriber with the given ID.
+func (t *TxrV2) UnsubscribeTxResults(id event.SubscriptionID) {
+	t.dispatcher.Unsubscribe(id)
 }
 
 // SendTxRequest adds the given tx request to the tx queue, after validating it.
-func (t *TxrV2) SendTxRequest(txReq *types.Request) (string, error) {
+func (t *TxrV2) SendTxRequest(ctx context.Context, txReq *types.Request) (string, error) {
 	if err := txReq.Validate(); err != nil {
 		return "", err
 	}
 
 	msgID := txReq.MsgID
-	queueID, err := t.requests.Push(txReq)
+	queueID, err := t.requests.Push(ctx, txReq)
 	if err != nil {
 		return "", err
 	}
@@ -154,6 +275,7 @@ func (t *TxrV2) SendTxRequest(txReq *types.Request) (string, error) {
 		msgID = queueID
 	}
 
+	t.metrics.IncMonotonic("transactor.requests.queued", nil)
 	t.markState(types.StateQueued, msgID)
 	return msgID, nil
 }
@@ -183,6 +305,14 @@ func (t *TxrV2) GetPreconfirmedState(msgID string) types.PreconfirmedState {
 	return t.preconfirmedStates[msgID]
 }
 
+// isInProcess returns true if the given message ID is being built into a tx, sent, or tracked.
+func (t *TxrV2) isInProcess(msgID string) bool {
+	if msgID == "" {
+		return false
+	}
+	return t.GetPreconfirmedState(msgID) > types.StateQueued
+}
+
 // markState marks the given preconfirmed state for the given message IDs.
 func (t *TxrV2) markState(state types.PreconfirmedState, msgIDs ...string) {
 	t.preconfirmedMu.Lock()
@@ -216,6 +346,7 @@ func (t *TxrV2) resendStaleTxns(ctx context.Context, chain eth.Client) error {
 	if pendingTxs := txPoolContent["pending"]; len(pendingTxs) > 0 {
 		t.logger.Info("🔄 resending stale (pending in txpool) txs", "count", len(pendingTxs))
 		for _, tx := range pendingTxs {
+			t.metrics.IncMonotonic("transactor.tx.replacements", []string{"reason:startup_pending"})
 			t.fire(ctx, &tracker.Response{Transaction: sender.BumpGas(tx)}, false)
 		}
 	}
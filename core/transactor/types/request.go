@@ -7,12 +7,19 @@ import (
 	"strings"
 	"time"
 
+	"github.com/berachain/offchain-sdk/types/queue/sqs"
 	"github.com/berachain/offchain-sdk/types/queue/types"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// contractCreationGroupID is the FIFO message group of requests that deploy a contract.
+const contractCreationGroupID = "create"
+
+var _ sqs.FIFOMessage = (*Request)(nil)
+
 // Request is a transaction request, using the go-ethereum call msg.
 type Request struct {
 	// CallMsg is used to provide the basic tx data. The From field is ignored for txs, only used
@@ -83,6 +90,25 @@ func (r *Request) Unmarshal(data []byte) error {
 	return json.Unmarshal(data, r)
 }
 
+// MessageGroupID implements sqs.FIFOMessage. Requests to the same address are received in order,
+// while requests to different addresses may be received in parallel.
+func (r *Request) MessageGroupID() string {
+	if r.To == nil {
+		return contractCreationGroupID
+	}
+	return r.To.Hex()
+}
+
+// DeduplicationID implements sqs.FIFOMessage. Requests with the same message ID (or the same
+// content, if no message ID is provided) are deduplicated.
+func (r *Request) DeduplicationID() string {
+	if r.MsgID != "" {
+		return crypto.Keccak256Hash([]byte(r.MsgID)).Hex()[2:]
+	}
+	bz, _ := r.Marshal()
+	return crypto.Keccak256Hash(bz).Hex()[2:]
+}
+
 // Requests is a list of requests.
 type Requests []*Request
 
@@ -47,6 +47,10 @@ func (app *ListenerApp) Setup(
 		return
 	}
 
+	// Record job executions, and report the health checks, to the metrics.
+	ab.RegisterMetrics(app.metrics)
+	ab.RegisterHealthReportInterval(config.Metrics.HealthReportInterval)
+
 	// Spin up Prometheus HTTP server
 	if config.Metrics.Prometheus.Enabled {
 		if err = ab.RegisterPrometheusTelemetry(); err != nil {
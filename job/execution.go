@@ -0,0 +1,42 @@
+package job
+
+// ============================================
+// Execution Configs
+// ============================================
+
+// ExecutionConfig configures how the executions of a job are run by the job manager. The zero
+// value runs executions in the shared executor pool, without limits. Execution configs can be
+// declared by jobs (see HasExecutionConfig) and overridden, by registry key, in the `Jobs`
+// section of the app config file.
+type ExecutionConfig struct {
+	// Pool is the name of a dedicated worker pool to run the job's executions in, which is shared
+	// by all jobs with the same pool name. If empty, the shared executor pool is used.
+	Pool string
+	// MaxConcurrency is the max number of the job's executions that are submitted (running or
+	// queued) at the same time. Further executions block the job's producer (and only the job's
+	// producer) until one finishes. If 0, executions are only limited by the pool.
+	MaxConcurrency uint16
+	// Serial runs the job's executions one at a time, in the order they were produced. If the job
+	// implements HasOrderingKey, only executions with the same key are run one at a time.
+	Serial bool
+	// BufferSize is the number of values of a subscription job (Subscribable, EthSubscribable or
+	// BlockHeaderSub) that are buffered between the subscription and the executor, so that the
+	// subscription keeps being read while executions are blocked. If 0, values are not buffered.
+	BufferSize uint16
+	// Singleton runs the job's producer only on the replica elected leader, when leader election
+	// is enabled (see the `LeaderElection` section of the app config file).
+	Singleton bool
+}
+
+// HasExecutionConfig represents a job that declares how its executions are run. It can also be
+// implemented by typed jobs (see Typed).
+type HasExecutionConfig interface {
+	ExecutionConfig() ExecutionConfig
+}
+
+// HasOrderingKey represents a job whose executions are ordered by key (e.g. an account or a
+// contract address), when its execution config is Serial.
+type HasOrderingKey interface {
+	Basic
+	OrderingKey(args any) string
+}
@@ -1,6 +1,42 @@
 package job
 
+import (
+	"context"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+)
+
 type WorkerPool interface {
 	Submit(func())
 	SubmitAndWait(func())
 }
+
+// JobSubmitter is implemented by worker pools that can submit the executions of other jobs (e.g.
+// downstream consumers) according to those jobs' execution configs.
+type JobSubmitter interface {
+	SubmitJob(j jobtypes.Executable, args any, execute func())
+}
+
+// NewPayload returns the payload of an execution of the given job, with the given input, to be
+// submitted to the given pool. The payloads of the job's downstream consumers are submitted to the
+// pool, and the execution is observed by the pool if it is a jobtypes.Observer.
+func NewPayload(
+	ctx context.Context, pool WorkerPool, j jobtypes.Executable, args any,
+) *jobtypes.Payload {
+	payload := jobtypes.NewPayload(ctx, j, args).WithSubmitter(SubmitterOf(pool))
+	if observer, ok := pool.(jobtypes.Observer); ok {
+		payload = payload.WithObserver(observer)
+	}
+	return payload
+}
+
+// SubmitterOf returns a submitter for the payloads of downstream consumers that submits them to
+// the given pool.
+func SubmitterOf(pool WorkerPool) jobtypes.Submitter {
+	if js, ok := pool.(JobSubmitter); ok {
+		return js.SubmitJob
+	}
+	return func(_ jobtypes.Executable, _ any, execute func()) {
+		pool.Submit(execute)
+	}
+}
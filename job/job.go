@@ -2,6 +2,8 @@ package job
 
 import (
 	"context"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 )
 
 // Basic represents a basic job. Borrowing the terminology from inheritance, we can
@@ -25,6 +27,27 @@ type HasTeardown interface {
 	Teardown() error
 }
 
+// HasPolicy represents a job that defines a policy (timeout, retries, backoff, etc.) for its
+// executions, which is honored by the job manager.
+type HasPolicy interface {
+	Basic
+	Policy() jobtypes.Policy
+}
+
+// HasErrorHandler represents a job that handles its own failed executions, i.e. those that still
+// fail after all attempts of its policy.
+type HasErrorHandler interface {
+	Basic
+	HandleError(ctx context.Context, args any, err error)
+}
+
+// HasConsumers represents a job whose results are passed on to downstream consumer jobs (see
+// `pipeline.go`).
+type HasConsumers interface {
+	Basic
+	Consumers() []jobtypes.Executable
+}
+
 // HasProducer represents a struct that defines a producer.
 type HasProducer interface {
 	Basic
@@ -0,0 +1,200 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+)
+
+// ============================================
+// Pipelines
+// ============================================
+
+// Jobs can be chained into pipelines by implementing HasConsumers: each successful, non-nil
+// result of a job is submitted (to the executor pool) as the input of every one of its consumers.
+// Consumers may themselves have consumers, e.g.
+//
+//	event decoder (EthSubscribable) -> DB writer
+//	                                -> Pipe(request builder, transactor sender)
+//
+// Consumers that are not registered with the app are set up and torn down alongside the job that
+// declares them. Returning a nil result ends the pipeline for that execution.
+
+// Typed represents a basic job with a typed input and output. Use WrapTyped to adapt it into a
+// Basic job, e.g. to use it as a consumer.
+type Typed[In, Out any] interface {
+	RegistryKey() string
+	Execute(ctx context.Context, in In) (Out, error)
+}
+
+// Compile time check to ensure that the typed and pipe adapters implement the optional
+// interfaces of the jobs they wrap.
+var (
+	_ HasSetup           = (*typed[any, any])(nil)
+	_ HasTeardown        = (*typed[any, any])(nil)
+	_ HasPolicy          = (*typed[any, any])(nil)
+	_ jobtypes.Bridge    = (*typed[any, any])(nil)
+	_ jobtypes.Unwrapper = (*typed[any, any])(nil)
+	_ HasConsumers       = (*pipe)(nil)
+	_ HasSetup           = (*pipe)(nil)
+	_ HasTeardown        = (*pipe)(nil)
+)
+
+// WrapTyped adapts a typed job into a basic job. Executing it with an input that is not an In
+// fails with an error.
+func WrapTyped[In, Out any](t Typed[In, Out]) Basic {
+	return &typed[In, Out]{t}
+}
+
+// Func returns a basic job, with the given registry key, that executes the given typed function.
+func Func[In, Out any](key string, fn func(context.Context, In) (Out, error)) Basic {
+	return WrapTyped[In, Out](&funcJob[In, Out]{key: key, fn: fn})
+}
+
+// Pipe returns a basic job that executes j and passes its results on to the given consumers.
+func Pipe(j Basic, consumers ...Basic) Basic {
+	executables := make([]jobtypes.Executable, len(consumers))
+	for i, c := range consumers {
+		executables[i] = c
+	}
+	return &pipe{Basic: j, consumers: executables}
+}
+
+// typed is a wrapper for a typed job.
+type typed[In, Out any] struct {
+	Typed[In, Out]
+}
+
+// Execute asserts the input is an In before executing the typed job.
+func (t *typed[In, Out]) Execute(ctx context.Context, args any) (any, error) {
+	in, ok := args.(In)
+	if !ok {
+		return nil, fmt.Errorf(
+			"job %s: unexpected input type %T, expected %T", t.RegistryKey(), args, *new(In),
+		)
+	}
+	return t.Typed.Execute(ctx, in)
+}
+
+// Setup calls the typed job's Setup, if it has one.
+func (t *typed[In, Out]) Setup(ctx context.Context) error {
+	if sj, ok := t.Typed.(interface{ Setup(context.Context) error }); ok {
+		return sj.Setup(ctx)
+	}
+	return nil
+}
+
+// Teardown calls the typed job's Teardown, if it has one.
+func (t *typed[In, Out]) Teardown() error {
+	if tj, ok := t.Typed.(interface{ Teardown() error }); ok {
+		return tj.Teardown()
+	}
+	return nil
+}
+
+// Policy returns the typed job's policy, if it has one.
+func (t *typed[In, Out]) Policy() jobtypes.Policy {
+	if pj, ok := t.Typed.(jobtypes.HasPolicy); ok {
+		return pj.Policy()
+	}
+	return jobtypes.Policy{}
+}
+
+// Bridged implements jobtypes.Bridge, so that the typed job's optional interfaces (e.g.
+// HasRevertHandler, HasReorgConfig or HasExecutionConfig) are honored.
+func (t *typed[In, Out]) Bridged() any {
+	return t.Typed
+}
+
+// Unwrap implements jobtypes.Unwrapper, unwrapping the typed job if it wraps another job.
+func (t *typed[In, Out]) Unwrap() jobtypes.Executable {
+	if unwrapper, ok := t.Typed.(jobtypes.Unwrapper); ok {
+		return unwrapper.Unwrap()
+	}
+	return nil
+}
+
+// funcJob is a typed job that executes a function.
+type funcJob[In, Out any] struct {
+	key string
+	fn  func(context.Context, In) (Out, error)
+}
+
+// RegistryKey implements Typed.
+func (f *funcJob[In, Out]) RegistryKey() string {
+	return f.key
+}
+
+// Execute implements Typed.
+func (f *funcJob[In, Out]) Execute(ctx context.Context, in In) (Out, error) {
+	return f.fn(ctx, in)
+}
+
+// pipe is a wrapper for a basic job with consumers.
+type pipe struct {
+	Basic
+	consumers []jobtypes.Executable
+}
+
+// Consumers implements HasConsumers.
+func (p *pipe) Consumers() []jobtypes.Executable {
+	return p.consumers
+}
+
+// Unwrap implements jobtypes.Unwrapper.
+func (p *pipe) Unwrap() jobtypes.Executable {
+	return p.Basic
+}
+
+// Setup calls the wrapped job's Setup, if it has one.
+func (p *pipe) Setup(ctx context.Context) error {
+	if sj, ok := p.Basic.(HasSetup); ok {
+		return sj.Setup(ctx)
+	}
+	return nil
+}
+
+// Teardown calls the wrapped job's Teardown, if it has one.
+func (p *pipe) Teardown() error {
+	if tj, ok := p.Basic.(HasTeardown); ok {
+		return tj.Teardown()
+	}
+	return nil
+}
+
+// ConsumersOf returns, in order and without duplicates (by registry key), all the downstream
+// consumers of the given job, through the jobs it wraps and the consumers' own consumers.
+func ConsumersOf(j Basic) []Basic {
+	var (
+		consumers []Basic
+		seen      = map[string]struct{}{j.RegistryKey(): {}}
+		visit     func(jobtypes.Executable)
+	)
+	visit = func(e jobtypes.Executable) {
+		for e != nil {
+			if hc, ok := e.(jobtypes.HasConsumers); ok {
+				for _, c := range hc.Consumers() {
+					b, isBasic := c.(Basic)
+					if !isBasic {
+						continue
+					}
+					if _, dup := seen[b.RegistryKey()]; dup {
+						continue
+					}
+					seen[b.RegistryKey()] = struct{}{}
+					consumers = append(consumers, b)
+					visit(b)
+				}
+				return
+			}
+			unwrapper, ok := e.(jobtypes.Unwrapper)
+			if !ok {
+				return
+			}
+			e = unwrapper.Unwrap()
+		}
+	}
+	visit(j)
+	return consumers
+}
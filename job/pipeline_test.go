@@ -0,0 +1,63 @@
+package job_test
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPipeline(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		written []string
+		summed  []int
+	)
+	write := job.Func("writer", func(_ context.Context, s string) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		written = append(written, s)
+		return nil, nil
+	})
+	sum := job.Func("summer", func(_ context.Context, n int) (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		summed = append(summed, n)
+		return nil, nil
+	})
+	decode := job.Pipe(
+		job.Func("decoder", func(_ context.Context, n int) (string, error) {
+			return strconv.Itoa(n), nil
+		}),
+		write, sum, // sum fails: it expects an int, but the decoder outputs a string.
+	)
+
+	ctx := sdk.NewContext(context.Background(), nil, log.NewLogger(os.Stdout, "test-runner"), nil)
+	jobtypes.NewPayload(ctx, decode, 42).Execute()
+	require.Equal(t, []string{"42"}, written)
+	require.Empty(t, summed)
+
+	// Consumers are submitted, if a submitter is set.
+	var submitted int
+	jobtypes.NewPayload(ctx, decode, 7).WithSubmitter(func(_ jobtypes.Executable, _ any, f func()) {
+		submitted++
+		f()
+	}).Execute()
+	require.Equal(t, 2, submitted)
+	require.Equal(t, []string{"42", "7"}, written)
+
+	// Consumers are found through the jobs they are chained to, without duplicates.
+	source := job.Func("source", func(context.Context, any) (int, error) { return 1, nil })
+	consumers := job.ConsumersOf(job.Pipe(source, decode, write))
+	require.Len(t, consumers, 3)
+	require.Equal(t, "decoder", consumers[0].RegistryKey())
+	require.Equal(t, "writer", consumers[1].RegistryKey())
+	require.Equal(t, "summer", consumers[2].RegistryKey())
+}
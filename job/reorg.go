@@ -0,0 +1,53 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ============================================
+// Reorgs
+// ============================================
+
+// ReorgConfig configures how the job manager handles chain reorgs for an event (EthSubscribable)
+// or block header (BlockHeaderSub) job. The zero value executes logs and blocks as soon as they
+// are received, and tracks the last 64 blocks to detect reorgs.
+type ReorgConfig struct {
+	// FinalityDepth is the number of blocks that must be built on top of a block before its logs
+	// (or header) are final, and executed. Logs and blocks reverted before they are final are
+	// dropped without being executed. If 0, they are executed as soon as they are received.
+	FinalityDepth uint64
+	// History is the number of recent blocks whose hashes are tracked to detect reorgs. Reorgs
+	// deeper than History (or FinalityDepth, if larger) are not detected. Defaults to 64.
+	History uint64
+	// PollInterval is how often the head of the chain is polled to finalize the logs of an event
+	// job, if FinalityDepth is set. Defaults to 2s.
+	PollInterval time.Duration
+}
+
+// HasReorgConfig represents an event or block header job that declares how reorgs are handled. It
+// can also be implemented by typed jobs (see Typed), e.g. the handlers of NewEventSub.
+type HasReorgConfig interface {
+	ReorgConfig() ReorgConfig
+}
+
+// LogRemoved is the event of an executed log that was removed from the canonical chain by a reorg.
+type LogRemoved struct {
+	Log coretypes.Log
+}
+
+// BlockReverted is the event of an executed block that was removed from the canonical chain by a
+// reorg.
+type BlockReverted struct {
+	Header *coretypes.Header
+}
+
+// HasRevertHandler represents an event or block header job that handles reorgs. For every log (or
+// block) that was executed and then removed from the canonical chain, Revert is passed a
+// LogRemoved (or BlockReverted) event, newest first. Without a revert handler, removed logs and
+// reverted blocks are dropped. It can also be implemented by typed jobs (see Typed).
+type HasRevertHandler interface {
+	Revert(ctx context.Context, event any) error
+}
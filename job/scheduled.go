@@ -0,0 +1,205 @@
+package job
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"math/big"
+	"time"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/robfig/cron/v3"
+)
+
+// maxMissedRuns bounds the number of missed runs that are caught up by MissedRunCatchUpAll (e.g.
+// after a long downtime of a frequent schedule); only the most recent ones are run.
+const maxMissedRuns = 1000
+
+// lastRunKeyPrefix is the key prefix, in the app DB, of the time of the last run of each
+// scheduled job.
+var lastRunKeyPrefix = []byte("job/scheduled/last-run/") //nolint:gochecknoglobals // constant.
+
+// MissedRunPolicy determines what a scheduled job does about runs that were missed, either because
+// the app was down or because a previous run took longer than the time between runs.
+type MissedRunPolicy uint8
+
+const (
+	// MissedRunSkip skips all missed runs, waiting for the next scheduled time.
+	MissedRunSkip MissedRunPolicy = iota
+	// MissedRunCatchUpOnce runs once immediately (for the latest missed time) if any runs were
+	// missed.
+	MissedRunCatchUpOnce
+	// MissedRunCatchUpAll runs once immediately for every missed time, in order.
+	MissedRunCatchUpAll
+)
+
+// ScheduleConfig configures when a scheduled job runs.
+type ScheduleConfig struct {
+	// Cron is a standard (5 field) cron expression, or a descriptor such as "@hourly".
+	Cron string
+	// Location is the timezone the cron expression is evaluated in. Defaults to UTC.
+	Location *time.Location
+	// Jitter is the max random delay added to each run, to spread out the load of many replicas
+	// or jobs on the same schedule.
+	Jitter time.Duration
+	// MissedRuns is the policy for runs missed since the last run. The time of the last run is
+	// persisted in the app DB (if one is registered) to detect runs missed during downtime.
+	MissedRuns MissedRunPolicy
+}
+
+// Scheduled represents a scheduled job. Scheduled jobs are run at the (wall-clock) times given by
+// a cron expression, rather than at an interval after the previous run. The scheduled time of each
+// run is passed as the input to Execute.
+type Scheduled interface {
+	Basic
+	Schedule() ScheduleConfig
+}
+
+// WrapScheduled wraps a scheduled job to conform to the producer interface.
+func WrapScheduled(s Scheduled) HasProducer {
+	return &scheduled{s}
+}
+
+// scheduled is a wrapper for a scheduled job.
+type scheduled struct {
+	Scheduled
+}
+
+// Unwrap implements jobtypes.Unwrapper.
+func (sj *scheduled) Unwrap() jobtypes.Executable {
+	return sj.Scheduled
+}
+
+// Producer runs the job at every time of its schedule, one run at a time.
+func (sj *scheduled) Producer(ctx context.Context, pool WorkerPool) error {
+	cfg := sj.Schedule()
+	schedule, err := cron.ParseStandard(cfg.Cron)
+	if err != nil {
+		return err
+	}
+	loc := cfg.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	// Resume from the last run, if one was persisted. Otherwise, start from now.
+	db, logger := appDB(ctx), appLogger(ctx)
+	last := sj.loadLastRun(db)
+	if last.IsZero() {
+		last = time.Now()
+	}
+	last = last.In(loc)
+
+	setLast := func(at time.Time) {
+		last = at
+		if err = sj.storeLastRun(db, last); err != nil && logger != nil {
+			logger.Error("error storing last run of scheduled job", "job", sj.RegistryKey(),
+				"err", err)
+		}
+	}
+	run := func(at time.Time) {
+		pool.SubmitAndWait(NewPayload(ctx, pool, sj, at).Execute)
+		// If stopped, the run may not have been executed: leave it to be caught up.
+		if ctx.Err() == nil {
+			setLast(at)
+		}
+	}
+
+	for {
+		// Handle any runs missed since the last run, according to the policy.
+		if missed := missedRuns(schedule, last, time.Now().In(loc)); len(missed) > 0 {
+			switch cfg.MissedRuns {
+			case MissedRunSkip:
+				setLast(missed[len(missed)-1])
+			case MissedRunCatchUpOnce:
+				run(missed[len(missed)-1])
+			case MissedRunCatchUpAll:
+				for _, at := range missed {
+					run(at)
+				}
+			}
+			continue
+		}
+
+		// Wait until the next scheduled time (plus jitter), then run.
+		next := schedule.Next(last)
+		timer := time.NewTimer(time.Until(next) + randomJitter(cfg.Jitter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+			run(next)
+		}
+	}
+}
+
+// loadLastRun returns the persisted time of the last run, or the zero time if there is none.
+func (sj *scheduled) loadLastRun(db ethdb.KeyValueStore) time.Time {
+	if db == nil {
+		return time.Time{}
+	}
+	bz, err := db.Get(sj.lastRunKey())
+	if err != nil || len(bz) != 8 { //nolint:gomnd // uint64.
+		return time.Time{}
+	}
+	return time.Unix(0, int64(binary.BigEndian.Uint64(bz)))
+}
+
+// storeLastRun persists the time of the last run, if there is an app DB.
+func (sj *scheduled) storeLastRun(db ethdb.KeyValueStore, last time.Time) error {
+	if db == nil {
+		return nil
+	}
+	return db.Put(sj.lastRunKey(), binary.BigEndian.AppendUint64(nil, uint64(last.UnixNano())))
+}
+
+// lastRunKey returns the app DB key of the time of the last run of the job.
+func (sj *scheduled) lastRunKey() []byte {
+	return append(append([]byte{}, lastRunKeyPrefix...), sj.RegistryKey()...)
+}
+
+// missedRuns returns, in order, the (at most maxMissedRuns most recent) scheduled times after last
+// and no later than now.
+func missedRuns(schedule cron.Schedule, last, now time.Time) []time.Time {
+	var missed []time.Time
+	for at := schedule.Next(last); !at.IsZero() && !at.After(now); at = schedule.Next(at) {
+		if len(missed) == maxMissedRuns {
+			missed = missed[1:]
+		}
+		missed = append(missed, at)
+	}
+	return missed
+}
+
+// randomJitter returns a random duration in [0, jitter).
+func randomJitter(jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return 0
+	}
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(jitter)))
+	if err != nil {
+		return 0
+	}
+	return time.Duration(n.Int64())
+}
+
+// appLogger returns the logger of the sdk context, if any.
+func appLogger(ctx context.Context) log.Logger {
+	if sCtx, ok := ctx.(*sdk.Context); ok {
+		return sCtx.Logger()
+	}
+	return nil
+}
+
+// appDB returns the app DB of the sdk context, if any.
+func appDB(ctx context.Context) ethdb.KeyValueStore {
+	if sCtx, ok := ctx.(*sdk.Context); ok {
+		return sCtx.DB()
+	}
+	return nil
+}
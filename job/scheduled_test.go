@@ -0,0 +1,80 @@
+package job
+
+import (
+	"context"
+	"encoding/binary"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+// syncPool runs every submitted task synchronously.
+type syncPool struct{}
+
+func (syncPool) Submit(f func())        { f() }
+func (syncPool) SubmitAndWait(f func()) { f() }
+
+// everyMinute is a scheduled job that records the scheduled time of each run.
+type everyMinute struct {
+	policy MissedRunPolicy
+
+	mu   sync.Mutex
+	runs []time.Time
+}
+
+func (*everyMinute) RegistryKey() string { return "every-minute" }
+
+func (j *everyMinute) Schedule() ScheduleConfig {
+	return ScheduleConfig{Cron: "* * * * *", MissedRuns: j.policy}
+}
+
+func (j *everyMinute) Execute(_ context.Context, args any) (any, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.runs = append(j.runs, args.(time.Time))
+	return nil, nil //nolint:nilnil // test job.
+}
+
+func TestMissedRunPolicies(t *testing.T) {
+	// Avoid crossing a minute boundary while the test runs.
+	if untilNext := time.Until(time.Now().Truncate(time.Minute).Add(time.Minute)); untilNext < time.Second {
+		time.Sleep(untilNext)
+	}
+
+	// The last run was 3 full minutes ago, so 3 runs were missed.
+	lastRun := time.Now().UTC().Truncate(time.Minute).Add(-3 * time.Minute)
+
+	for policy, expected := range map[MissedRunPolicy]int{
+		MissedRunSkip:        0,
+		MissedRunCatchUpOnce: 1,
+		MissedRunCatchUpAll:  3,
+	} {
+		db := memorydb.New()
+		j := &everyMinute{policy: policy}
+		sj := &scheduled{j}
+		require.NoError(t, db.Put(
+			sj.lastRunKey(), binary.BigEndian.AppendUint64(nil, uint64(lastRun.UnixNano())),
+		))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		sCtx := sdk.NewContext(ctx, nil, log.NewLogger(os.Stdout, "test-runner"), db)
+		require.ErrorIs(t, sj.Producer(sCtx, syncPool{}), context.DeadlineExceeded)
+		cancel()
+
+		// Missed runs are for the (wall-clock aligned) scheduled times, oldest first.
+		require.Len(t, j.runs, expected, "policy %d", policy)
+		for i, at := range j.runs {
+			require.Equal(t, lastRun.Add(time.Duration(3-len(j.runs)+i+1)*time.Minute), at)
+		}
+
+		// The latest missed time is persisted as the last run, whether run or skipped.
+		require.Equal(t, lastRun.Add(3*time.Minute), sj.loadLastRun(db).UTC())
+	}
+}
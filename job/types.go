@@ -15,6 +15,8 @@ func WrapJob(j Basic) HasProducer {
 	var wrappedJob HasProducer
 	if prodJob, ok := j.(HasProducer); ok {
 		wrappedJob = prodJob
+	} else if schedJob, ok := j.(Scheduled); ok { //nolint:govet // can't avoid.
+		wrappedJob = WrapScheduled(schedJob)
 	} else if condJob, ok := j.(Conditional); ok { //nolint:govet // can't avoid.
 		wrappedJob = WrapConditional(condJob)
 	} else if pollJob, ok := j.(Polling); ok { //nolint:govet // can't avoid.
@@ -24,7 +26,8 @@ func WrapJob(j Basic) HasProducer {
 }
 
 // After Basic jobs as explained in `job.go` the SDK currently
-// supports two other types of jobs, polling jobs and conditional jobs.
+// supports three other types of jobs, polling jobs, conditional jobs and
+// scheduled jobs (see `scheduled.go`).
 
 // ============================================
 // Polling Jobs
@@ -52,6 +55,11 @@ type polling struct {
 	Polling
 }
 
+// Unwrap implements jobtypes.Unwrapper.
+func (p *polling) Unwrap() jobtypes.Executable {
+	return p.Polling
+}
+
 // Condition always returns true for polling jobs.
 func (p *polling) Condition(context.Context) bool {
 	return true
@@ -77,6 +85,11 @@ type conditional struct {
 	Conditional
 }
 
+// Unwrap implements jobtypes.Unwrapper.
+func (cj *conditional) Unwrap() jobtypes.Executable {
+	return cj.Conditional
+}
+
 // ConditionalProducer produces a job when the condition is met.
 func (cj *conditional) Producer(ctx context.Context, pool WorkerPool) error {
 	for {
@@ -87,7 +100,7 @@ func (cj *conditional) Producer(ctx context.Context, pool WorkerPool) error {
 		default:
 			// Check if the condition is true.
 			if cj.Condition(ctx) {
-				pool.SubmitAndWait(jobtypes.NewPayload(ctx, cj, nil).Execute)
+				pool.SubmitAndWait(NewPayload(ctx, pool, cj, nil).Execute)
 			}
 		}
 
@@ -2,6 +2,12 @@ package types
 
 import (
 	"context"
+	"errors"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
+	sdk "github.com/berachain/offchain-sdk/types"
 )
 
 type Executable interface {
@@ -19,6 +25,12 @@ type Payload struct {
 
 	// args is the input function arguments.
 	args any
+
+	// submit (optional) submits the payloads of downstream consumers to be executed.
+	submit Submitter
+
+	// observer (optional) observes the outcome of the execution.
+	observer Observer
 }
 
 // NewPayload creates a new payload to send to a worker.
@@ -30,7 +42,165 @@ func NewPayload(ctx context.Context, job Executable, args any) *Payload {
 	}
 }
 
-// Execute executes the job and returns the result.
+// Submitter submits the execution of the given job, with the given input, to be executed.
+type Submitter func(job Executable, args any, execute func())
+
+// WithSubmitter sets how the payloads of the job's downstream consumers (see HasConsumers) are
+// submitted to be executed, e.g. to a worker pool. If not set, they are executed inline.
+func (p *Payload) WithSubmitter(submit Submitter) *Payload {
+	p.submit = submit
+	return p
+}
+
+// Observer observes the outcome of the executions of jobs (after all attempts), e.g. to keep
+// per-job statistics.
+type Observer interface {
+	ObserveExecution(job Executable, start time.Time, err error)
+}
+
+// WithObserver sets the observer of the execution, and of those of the job's downstream
+// consumers.
+func (p *Payload) WithObserver(observer Observer) *Payload {
+	p.observer = observer
+	return p
+}
+
+// Execute executes the job, honoring the job's policy (see HasPolicy). Failures, after all
+// attempts, are logged, recorded to metrics and passed to the job's error handler (see
+// HasErrorHandler) and the policy's OnFailure hook.
 func (p Payload) Execute() {
-	_, _ = p.job.Execute(p.ctx, p.args)
+	_ = p.Run()
+}
+
+// Run is like Execute, but returns the error of the execution after all attempts, e.g. for the
+// caller to acknowledge the input only if it was executed successfully.
+func (p Payload) Run() error {
+	var policy Policy
+	if hp, ok := As[HasPolicy](p.job); ok {
+		policy = hp.Policy()
+	}
+	policy = policy.withDefaults()
+
+	var (
+		logger, metrics = p.logger(), p.metrics()
+		key             = registryKey(p.job)
+		tags            = []string{"job:" + key}
+		start           = time.Now()
+		res             any
+		err             error
+	)
+	for attempt := 1; ; attempt++ {
+		if res, err = p.attempt(policy.Timeout); err == nil || attempt >= policy.MaxAttempts ||
+			!policy.retryable(err) || p.ctx.Err() != nil {
+			break
+		}
+
+		backoff := policy.Backoff(attempt)
+		if logger != nil {
+			logger.Warn("retrying job execution", "job", key, "attempt", attempt, "backoff", backoff,
+				"err", err)
+		}
+		if metrics != nil {
+			metrics.IncMonotonic("job.retries", tags)
+		}
+		select {
+		case <-p.ctx.Done():
+		case <-time.After(backoff):
+		}
+	}
+
+	if metrics != nil {
+		metrics.Time("job.duration", time.Since(start), tags)
+	}
+	if err == nil {
+		if metrics != nil {
+			metrics.IncMonotonic("job.executions", append(tags, "status:success"))
+		}
+		if p.observer != nil {
+			p.observer.ObserveExecution(p.job, start, nil)
+		}
+		p.dispatch(res)
+		return nil
+	}
+
+	// Executions cancelled by shutdown are not failures.
+	if errors.Is(err, context.Canceled) && p.ctx.Err() != nil {
+		return err
+	}
+	if metrics != nil {
+		metrics.IncMonotonic("job.executions", append(tags, "status:failure"))
+	}
+	if p.observer != nil {
+		p.observer.ObserveExecution(p.job, start, err)
+	}
+	if logger != nil {
+		logger.Error("job execution failed", "job", key, "err", err)
+	}
+	if policy.OnFailure != nil {
+		policy.OnFailure(p.ctx, p.args, err)
+	}
+	if eh, ok := As[HasErrorHandler](p.job); ok {
+		eh.HandleError(p.ctx, p.args, err)
+	}
+	return err
+}
+
+// dispatch passes the (non-nil) result of the job as the input to each of its downstream
+// consumers, if any.
+func (p Payload) dispatch(res any) {
+	hc, ok := As[HasConsumers](p.job)
+	if !ok || res == nil {
+		return
+	}
+	for _, consumer := range hc.Consumers() {
+		payload := &Payload{
+			job: consumer, ctx: p.ctx, args: res, submit: p.submit, observer: p.observer,
+		}
+		if p.submit != nil {
+			p.submit(consumer, res, payload.Execute)
+		} else {
+			payload.Execute()
+		}
+	}
+}
+
+// attempt executes the job once, with the given timeout (if non-zero).
+func (p Payload) attempt(timeout time.Duration) (any, error) {
+	ctx := p.ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+
+		// Jobs expect to be able to unwrap the sdk context.
+		if sCtx, ok := p.ctx.(*sdk.Context); ok {
+			ctx = sCtx.WithContext(ctx)
+		}
+	}
+
+	return p.job.Execute(ctx, p.args)
+}
+
+// logger returns the logger of the payload's sdk context, if any.
+func (p Payload) logger() log.Logger {
+	if sCtx, ok := p.ctx.(*sdk.Context); ok {
+		return sCtx.Logger()
+	}
+	return nil
+}
+
+// metrics returns the metrics of the payload's sdk context, if any.
+func (p Payload) metrics() telemetry.Metrics {
+	if sCtx, ok := p.ctx.(*sdk.Context); ok {
+		return sCtx.Metrics()
+	}
+	return nil
+}
+
+// registryKey returns the registry key of the job, if it has one.
+func registryKey(job Executable) string {
+	if keyed, ok := As[interface{ RegistryKey() string }](job); ok {
+		return keyed.RegistryKey()
+	}
+	return "unknown"
 }
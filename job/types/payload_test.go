@@ -0,0 +1,97 @@
+package types_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+var errPermanent = errors.New("permanent")
+
+// failingJob fails every attempt with the configured errors (the last one repeating), recording
+// how many attempts were made and which error was handled.
+type failingJob struct {
+	policy   jobtypes.Policy
+	errs     []error
+	block    bool
+	attempts int
+	handled  error
+}
+
+func (*failingJob) RegistryKey() string { return "failing" }
+
+func (j *failingJob) Policy() jobtypes.Policy { return j.policy }
+
+func (j *failingJob) HandleError(_ context.Context, _ any, err error) { j.handled = err }
+
+func (j *failingJob) Execute(ctx context.Context, _ any) (any, error) {
+	// Jobs must still be able to unwrap the sdk context.
+	_ = sdk.UnwrapContext(ctx)
+
+	j.attempts++
+	if j.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return nil, j.errs[min(j.attempts, len(j.errs))-1]
+}
+
+// wrapper wraps a job, as job producers do.
+type wrapper struct {
+	jobtypes.Executable
+}
+
+func (w wrapper) Unwrap() jobtypes.Executable { return w.Executable }
+
+func newContext() context.Context {
+	return sdk.NewContext(context.Background(), nil, log.NewLogger(os.Stdout, "test-runner"), nil)
+}
+
+func TestPolicyRetries(t *testing.T) {
+	j := &failingJob{
+		policy: jobtypes.Policy{
+			MaxAttempts: 5,
+			Backoff:     func(int) time.Duration { return time.Millisecond },
+			Retryable:   func(err error) bool { return !errors.Is(err, errPermanent) },
+		},
+		errs: []error{errors.New("transient"), errors.New("transient"), errPermanent},
+	}
+	jobtypes.NewPayload(newContext(), wrapper{j}, nil).Execute()
+
+	// Stops retrying at the first non-retryable error, which is handled.
+	require.Equal(t, 3, j.attempts)
+	require.ErrorIs(t, j.handled, errPermanent)
+}
+
+func TestPolicyTimeout(t *testing.T) {
+	var failed error
+	j := &failingJob{
+		policy: jobtypes.Policy{
+			Timeout:     10 * time.Millisecond,
+			MaxAttempts: 2,
+			Backoff:     func(int) time.Duration { return time.Millisecond },
+			OnFailure:   func(_ context.Context, _ any, err error) { failed = err },
+		},
+		block: true,
+	}
+	jobtypes.NewPayload(newContext(), j, nil).Execute()
+
+	require.Equal(t, 2, j.attempts)
+	require.ErrorIs(t, failed, context.DeadlineExceeded)
+	require.ErrorIs(t, j.handled, context.DeadlineExceeded)
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := jobtypes.ExponentialBackoff(time.Second, 5*time.Second)
+	require.Equal(t, time.Second, backoff(1))
+	require.Equal(t, 2*time.Second, backoff(2))
+	require.Equal(t, 4*time.Second, backoff(3))
+	require.Equal(t, 5*time.Second, backoff(4))
+}
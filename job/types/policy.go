@@ -0,0 +1,110 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	defaultBackoffStart = time.Second
+	defaultBackoffMax   = time.Minute
+	backoffBase         = 2
+)
+
+// Policy defines how the executions of a job are run. The zero value runs each execution once,
+// without a timeout.
+type Policy struct {
+	// Timeout is the max duration of each attempt. The context passed to the job is cancelled
+	// when it expires. If 0, attempts do not time out.
+	Timeout time.Duration
+	// MaxAttempts is the max number of attempts of each execution. If 0, only 1 attempt is made.
+	MaxAttempts int
+	// Backoff returns how long to wait before the given retry (starting at 1). Defaults to an
+	// exponential backoff from 1s, up to 1m.
+	Backoff func(retry int) time.Duration
+	// Retryable returns whether an execution that failed with the given error should be retried.
+	// If nil, all errors are retried.
+	Retryable func(error) bool
+	// OnFailure is called (if set) when an execution fails after all attempts.
+	OnFailure func(ctx context.Context, args any, err error)
+}
+
+// ExponentialBackoff returns a backoff that doubles after every retry, from start up to limit.
+func ExponentialBackoff(start, limit time.Duration) func(int) time.Duration {
+	return func(retry int) time.Duration {
+		backoff := start
+		for i := 1; i < retry && backoff < limit; i++ {
+			backoff *= backoffBase
+		}
+		if backoff > limit {
+			backoff = limit
+		}
+		return backoff
+	}
+}
+
+// withDefaults returns the policy with any unset fields set to their defaults.
+func (p Policy) withDefaults() Policy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = 1
+	}
+	if p.Backoff == nil {
+		p.Backoff = ExponentialBackoff(defaultBackoffStart, defaultBackoffMax)
+	}
+	return p
+}
+
+// retryable returns whether the given error should be retried, according to the policy.
+func (p Policy) retryable(err error) bool {
+	return p.Retryable == nil || p.Retryable(err)
+}
+
+// HasPolicy is implemented by jobs that define a policy for their executions.
+type HasPolicy interface {
+	Policy() Policy
+}
+
+// HasErrorHandler is implemented by jobs that handle their own failed executions. HandleError is
+// called when an execution fails after all attempts of the job's policy.
+type HasErrorHandler interface {
+	HandleError(ctx context.Context, args any, err error)
+}
+
+// HasConsumers is implemented by jobs whose results are passed on to downstream consumer jobs.
+// Each successful, non-nil result is executed as the input of every consumer (fan-out).
+type HasConsumers interface {
+	Consumers() []Executable
+}
+
+// Unwrapper is implemented by jobs that wrap another job (e.g. to give it a producer), so that
+// the policy and error handler of the wrapped job are honored.
+type Unwrapper interface {
+	Unwrap() Executable
+}
+
+// Bridge is implemented by jobs that adapt a job that is not itself an Executable (e.g. a typed
+// job, whose input is typed), so that the optional interfaces of the adapted job are honored.
+type Bridge interface {
+	Bridged() any
+}
+
+// As returns the first job, from the given job through the jobs it wraps (or bridges), that is a T.
+func As[T any](job Executable) (T, bool) {
+	for job != nil {
+		if t, ok := job.(T); ok {
+			return t, true
+		}
+		if bridge, ok := job.(Bridge); ok {
+			if t, isT := bridge.Bridged().(T); isT {
+				return t, true
+			}
+		}
+		unwrapper, ok := job.(Unwrapper)
+		if !ok {
+			break
+		}
+		job = unwrapper.Unwrap()
+	}
+	var zero T
+	return zero, false
+}
@@ -22,6 +22,12 @@ type Handler struct {
 
 type Middleware func(http.Handler) http.Handler
 
+// HasHandlers is implemented by the components of the app (e.g. jobs) that serve HTTP handlers,
+// which are registered on the built-in server when the app is built.
+type HasHandlers interface {
+	Handlers() []*Handler
+}
+
 // Server is a server, that currently only supports HTTP.
 type Server struct {
 	cfg    *Config
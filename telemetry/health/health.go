@@ -0,0 +1,210 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/server"
+	"github.com/berachain/offchain-sdk/telemetry"
+)
+
+// Kind is the kind of a health check.
+type Kind uint8
+
+const (
+	// Liveness checks fail when the app is broken and must be restarted. They are also part of
+	// the readiness of the app.
+	Liveness Kind = iota
+	// Readiness checks fail when the app can not currently do its work (e.g. no RPC endpoint is
+	// healthy), but may recover on its own.
+	Readiness
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	if k == Liveness {
+		return "liveness"
+	}
+	return "readiness"
+}
+
+// Statuses of a check, or of a report.
+const (
+	StatusOK        = "ok"
+	StatusUnhealthy = "unhealthy"
+)
+
+const (
+	// HealthzPath and ReadyzPath are the paths of the health HTTP handlers.
+	HealthzPath = "/healthz"
+	ReadyzPath  = "/readyz"
+
+	// checkTimeout bounds the duration of a single check.
+	checkTimeout = 5 * time.Second
+)
+
+// Check checks the health of a component, returning an error if it is unhealthy.
+type Check func(ctx context.Context) error
+
+// Checker represents a component that checks its own health.
+type Checker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// CheckResult is the result of a check.
+type CheckResult struct {
+	Kind   string `json:"kind"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Report is the result of the checks of a kind.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// Healthy returns whether all checks of the report passed.
+func (r Report) Healthy() bool {
+	return r.Status == StatusOK
+}
+
+// check is a registered check.
+type check struct {
+	kind  Kind
+	check Check
+}
+
+// Registry is a registry of the health checks of the app's components.
+type Registry struct {
+	mu     sync.RWMutex
+	checks map[string]check
+}
+
+// NewRegistry creates a new, empty, registry.
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]check)}
+}
+
+// Register registers the given check of the given kind under the given name, replacing any check
+// already registered under it.
+func (r *Registry) Register(name string, kind Kind, c Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks[name] = check{kind: kind, check: c}
+}
+
+// Unregister removes the check registered under the given name, if any.
+func (r *Registry) Unregister(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.checks, name)
+}
+
+// Check runs, concurrently, the liveness checks if kind is Liveness, or all the checks if kind is
+// Readiness, returning their report.
+func (r *Registry) Check(ctx context.Context, kind Kind) Report {
+	r.mu.RLock()
+	checks := make(map[string]check, len(r.checks))
+	for name, c := range r.checks {
+		if c.kind <= kind {
+			checks[name] = c
+		}
+	}
+	r.mu.RUnlock()
+
+	var (
+		report = Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(checks))}
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+	)
+	for name, c := range checks {
+		wg.Add(1)
+		go func(name string, c check) {
+			defer wg.Done()
+			result := CheckResult{Kind: c.kind.String(), Status: StatusOK}
+			if err := run(ctx, c.check); err != nil {
+				result.Status, result.Error = StatusUnhealthy, err.Error()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if result.Status != StatusOK {
+				report.Status = StatusUnhealthy
+			}
+		}(name, c)
+	}
+	wg.Wait()
+	return report
+}
+
+// run runs the given check, with a timeout.
+func run(ctx context.Context, c Check) error {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+	return c(ctx)
+}
+
+// Handlers returns the health HTTP handlers, which respond with the JSON report of the checks,
+// with status 200 if healthy or 503 otherwise:
+//
+//	GET /healthz  liveness checks
+//	GET /readyz   all checks
+func (r *Registry) Handlers() []*server.Handler {
+	return []*server.Handler{
+		{Path: HealthzPath, Handler: r.handler(Liveness)},
+		{Path: ReadyzPath, Handler: r.handler(Readiness)},
+	}
+}
+
+// handler returns the HTTP handler of the checks of the given kind.
+func (r *Registry) handler(kind Kind) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		report := r.Check(req.Context(), kind)
+		code := http.StatusOK
+		if !report.Healthy() {
+			code = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(code)
+		_ = json.NewEncoder(w).Encode(report)
+	})
+}
+
+// ReportMetrics runs all the checks at the given interval, until the context is done, reporting
+// their results as gauges (1 if healthy, 0 otherwise): `health.check` for each check (tagged by
+// name), and `health.live` and `health.ready` for the app.
+func (r *Registry) ReportMetrics(
+	ctx context.Context, metrics telemetry.Metrics, interval time.Duration,
+) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report := r.Check(ctx, Readiness)
+			live := true
+			for name, result := range report.Checks {
+				healthy := result.Status == StatusOK
+				live = live && (healthy || result.Kind != Liveness.String())
+				metrics.Gauge("health.check", gauge(healthy), []string{"check:" + name}, 1)
+			}
+			metrics.Gauge("health.live", gauge(live), nil, 1)
+			metrics.Gauge("health.ready", gauge(report.Healthy()), nil, 1)
+		}
+	}
+}
+
+// gauge returns the gauge value of the given health.
+func gauge(healthy bool) float64 {
+	if healthy {
+		return 1
+	}
+	return 0
+}
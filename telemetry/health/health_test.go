@@ -0,0 +1,46 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	reg := NewRegistry()
+	reg.Register("producers", Liveness, func(context.Context) error { return nil })
+	reg.Register("eth", Readiness, func(context.Context) error { return errors.New("down") })
+
+	// Liveness only runs the liveness checks.
+	report := reg.Check(context.Background(), Liveness)
+	require.True(t, report.Healthy())
+	require.Len(t, report.Checks, 1)
+
+	report = reg.Check(context.Background(), Readiness)
+	require.False(t, report.Healthy())
+	require.Equal(t, CheckResult{Kind: "readiness", Status: StatusUnhealthy, Error: "down"},
+		report.Checks["eth"])
+
+	handlers := reg.Handlers()
+	for path, code := range map[string]int{
+		HealthzPath: http.StatusOK, ReadyzPath: http.StatusServiceUnavailable,
+	} {
+		for _, h := range handlers {
+			if h.Path != path {
+				continue
+			}
+			rec := httptest.NewRecorder()
+			h.Handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+			require.Equal(t, code, rec.Code, path)
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+		}
+	}
+
+	reg.Unregister("eth")
+	require.True(t, reg.Check(context.Background(), Readiness).Healthy())
+}
@@ -11,6 +11,8 @@ import (
 // Config serves as a global telemetry configuration.
 // Provide the required config for the desired telemetry backend(s).
 type Config struct {
+	// HealthReportInterval is the interval at which the app's health checks are reported as
+	// gauges (see health.Registry.ReportMetrics). If 0, they are not reported.
 	HealthReportInterval time.Duration
 
 	Datadog    datadog.Config
@@ -3,6 +3,7 @@ package prometheus
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -17,6 +18,8 @@ const (
 type metrics struct {
 	cfg *Config
 
+	// mu guards the vec maps below, as metrics may be recorded from many goroutines.
+	mu            sync.Mutex
 	gaugeVecs     map[string]*prometheus.GaugeVec
 	counterVecs   map[string]*prometheus.CounterVec
 	histogramVecs map[string]*prometheus.HistogramVec
@@ -49,6 +52,9 @@ func (p *metrics) Gauge(name string, value float64, tags []string, _ float64) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	gaugeVec, exists := p.gaugeVecs[name]
@@ -71,6 +77,9 @@ func (p *metrics) Incr(name string, tags []string) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	gaugeVec, exists := p.gaugeVecs[name]
@@ -93,6 +102,9 @@ func (p *metrics) Decr(name string, tags []string) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	gaugeVec, exists := p.gaugeVecs[name]
@@ -115,6 +127,9 @@ func (p *metrics) Count(name string, value int64, tags []string) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	counterVec, exists := p.counterVecs[name]
@@ -137,6 +152,9 @@ func (p *metrics) IncMonotonic(name string, tags []string) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	counterVec, exists := p.counterVecs[name]
@@ -164,6 +182,9 @@ func (p *metrics) Histogram(name string, value float64, tags []string, rate floa
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	histogramVec, exists := p.histogramVecs[name]
@@ -187,6 +208,9 @@ func (p *metrics) Time(name string, value time.Duration, tags []string) {
 		return
 	}
 
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	name = forceValidName(name)
 	labels, labelValues := parseTagsToLabelPairs(tags)
 	histogramVec, exists := p.histogramVecs[name]
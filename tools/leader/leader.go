@@ -0,0 +1,189 @@
+package leader
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/tools/store"
+)
+
+const (
+	defaultKey           = "offchain-sdk/leader"
+	defaultLeaseDuration = 15 * time.Second
+	renewsPerLease       = 3
+	idRandomBytes        = 4
+)
+
+// Config is the configuration of leader election.
+type Config struct {
+	// Enabled enables leader election. If disabled, every replica runs every job.
+	Enabled bool
+	// Key is the key of the leader lease, shared by the replicas of an app. Defaults to
+	// "offchain-sdk/leader".
+	Key string
+	// LeaseDuration is how long the leader holds the lease without renewing it, i.e. the max time
+	// without a leader when the leader dies. Defaults to 15s.
+	LeaseDuration time.Duration
+	// RenewInterval is how often the lease is renewed (by the leader) or tried to be acquired (by
+	// the other replicas). Defaults to a third of the lease duration.
+	RenewInterval time.Duration
+	// RedisAddr is the address of the Redis server holding the lease. If empty, the lease is held
+	// in memory, which only elects among the jobs of a single process.
+	RedisAddr string
+	// RedisClusterMode is whether the Redis server is a cluster.
+	RedisClusterMode bool
+}
+
+// withDefaults returns the config with any unset fields set to their defaults.
+func (c Config) withDefaults() Config {
+	if c.Key == "" {
+		c.Key = defaultKey
+	}
+	if c.LeaseDuration <= 0 {
+		c.LeaseDuration = defaultLeaseDuration
+	}
+	if c.RenewInterval <= 0 || c.RenewInterval >= c.LeaseDuration {
+		c.RenewInterval = c.LeaseDuration / renewsPerLease
+	}
+	return c
+}
+
+// Elector elects one of the replicas of an app as the leader, by holding a lease that it renews
+// periodically. Leadership is lost as soon as the lease is held by another replica. Renewals that
+// fail with an error (e.g. a transient Redis error) are tolerated until the lease could expire
+// before the next renewal, so that the old leader steps down before the lease can be acquired by
+// another replica.
+type Elector struct {
+	locker store.Locker
+	cfg    Config
+	id     string
+	logger log.Logger
+
+	mu        sync.RWMutex
+	isLeader  bool
+	listeners []func(isLeader bool)
+
+	// leaseExpiry is when the lease last acquired (or renewed) by the replica expires.
+	leaseExpiry time.Time
+}
+
+// New creates a new elector that holds its lease in the given locker.
+func New(locker store.Locker, cfg Config, logger log.Logger) *Elector {
+	return &Elector{
+		locker: locker,
+		cfg:    cfg.withDefaults(),
+		id:     newID(),
+		logger: logger,
+	}
+}
+
+// NewFromConfig creates a new elector that holds its lease in Redis, or in memory if no Redis
+// address is configured.
+func NewFromConfig(cfg Config, logger log.Logger) *Elector {
+	if cfg.RedisAddr == "" {
+		return New(store.NewInMemoryLocker(), cfg, logger)
+	}
+	return New(store.NewRedisLocker(cfg.RedisAddr, cfg.RedisClusterMode), cfg, logger)
+}
+
+// ID returns the ID of the replica, which identifies it as the holder of the lease.
+func (e *Elector) ID() string {
+	return e.id
+}
+
+// LeaseDuration returns how long the leader holds the lease without renewing it.
+func (e *Elector) LeaseDuration() time.Duration {
+	return e.cfg.LeaseDuration
+}
+
+// IsLeader returns whether the replica is currently the leader.
+func (e *Elector) IsLeader() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.isLeader
+}
+
+// OnChange registers a listener that is called (synchronously, from Run) when the replica becomes
+// or stops being the leader. Renewals are paused while listeners run, so a listener stepping down
+// (isLeader false) can finish before another replica is elected.
+func (e *Elector) OnChange(listener func(isLeader bool)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.listeners = append(e.listeners, listener)
+}
+
+// Run tries to acquire, then renew, the lease until the context is done, when it steps down and
+// releases the lease. It is blocking so must run in a go-routine.
+func (e *Elector) Run(ctx context.Context) {
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+
+	for {
+		e.tryAcquire(ctx)
+		select {
+		case <-ctx.Done():
+			e.setLeader(false)
+			// Release with a fresh context, as the run context is done.
+			releaseCtx, cancel := context.WithTimeout(context.Background(), e.cfg.RenewInterval)
+			defer cancel()
+			if err := e.locker.Release(releaseCtx, e.cfg.Key, e.id); err != nil {
+				e.logger.Error("error releasing leader lease", "err", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// tryAcquire tries to acquire (or renew) the lease, updating the leadership. If it fails with an
+// error, the leader only steps down if its lease could expire before the next renewal.
+func (e *Elector) tryAcquire(ctx context.Context) {
+	// The lease is conservatively assumed to start before it is acquired.
+	start := time.Now()
+	acquireCtx, cancel := context.WithTimeout(ctx, e.cfg.RenewInterval)
+	defer cancel()
+	acquired, err := e.locker.Acquire(acquireCtx, e.cfg.Key, e.id, e.cfg.LeaseDuration)
+	if err != nil {
+		e.logger.Error("error acquiring leader lease", "err", err)
+		e.setLeader(e.IsLeader() && time.Now().Add(e.cfg.RenewInterval).Before(e.leaseExpiry))
+		return
+	}
+	if acquired {
+		e.leaseExpiry = start.Add(e.cfg.LeaseDuration)
+	}
+	e.setLeader(acquired)
+}
+
+// setLeader updates the leadership, notifying the listeners if it changed.
+func (e *Elector) setLeader(isLeader bool) {
+	e.mu.Lock()
+	if e.isLeader == isLeader {
+		e.mu.Unlock()
+		return
+	}
+	e.isLeader = isLeader
+	listeners := e.listeners
+	e.mu.Unlock()
+
+	if isLeader {
+		e.logger.Info("elected leader", "id", e.id)
+	} else {
+		e.logger.Info("stepped down as leader", "id", e.id)
+	}
+	for _, listener := range listeners {
+		listener(isLeader)
+	}
+}
+
+// newID returns a unique ID for the replica: its hostname and a random suffix.
+func newID() string {
+	hostname, _ := os.Hostname()
+	suffix := make([]byte, idRandomBytes)
+	_, _ = rand.Read(suffix)
+	return hostname + "-" + hex.EncodeToString(suffix)
+}
@@ -0,0 +1,85 @@
+package leader_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/tools/leader"
+	"github.com/berachain/offchain-sdk/tools/store"
+	"github.com/stretchr/testify/require"
+)
+
+func TestElection(t *testing.T) {
+	var (
+		locker = store.NewInMemoryLocker()
+		cfg    = leader.Config{LeaseDuration: 30 * time.Millisecond}
+		logger = log.NewLogger(os.Stdout, "test-runner")
+		a, b   = leader.New(locker, cfg, logger), leader.New(locker, cfg, logger)
+	)
+	var changes atomic.Int32
+	a.OnChange(func(bool) { changes.Add(1) })
+
+	ctxA, cancelA := context.WithCancel(context.Background())
+	ctxB, cancelB := context.WithCancel(context.Background())
+	defer cancelB()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		a.Run(ctxA)
+	}()
+	require.Eventually(t, a.IsLeader, time.Second, time.Millisecond)
+	go b.Run(ctxB)
+
+	// Only one replica is the leader, until it steps down.
+	time.Sleep(50 * time.Millisecond)
+	require.True(t, a.IsLeader())
+	require.False(t, b.IsLeader())
+
+	cancelA()
+	<-done
+	require.False(t, a.IsLeader())
+	require.Equal(t, int32(2), changes.Load())
+	require.Eventually(t, b.IsLeader, time.Second, time.Millisecond)
+}
+
+// flakyLocker is a locker whose acquisitions fail with an error while failing is set.
+type flakyLocker struct {
+	store.Locker
+	failing atomic.Bool
+}
+
+func (l *flakyLocker) Acquire(
+	ctx context.Context, key, owner string, ttl time.Duration,
+) (bool, error) {
+	if l.failing.Load() {
+		return false, errors.New("connection reset")
+	}
+	return l.Locker.Acquire(ctx, key, owner, ttl)
+}
+
+func TestElectionToleratesRenewErrors(t *testing.T) {
+	locker := &flakyLocker{Locker: store.NewInMemoryLocker()}
+	cfg := leader.Config{LeaseDuration: 300 * time.Millisecond, RenewInterval: 20 * time.Millisecond}
+	e := leader.New(locker, cfg, log.NewLogger(os.Stdout, "test-runner"))
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go e.Run(ctx)
+	require.Eventually(t, e.IsLeader, time.Second, time.Millisecond)
+
+	// Transient errors do not step down the leader...
+	locker.failing.Store(true)
+	time.Sleep(100 * time.Millisecond)
+	require.True(t, e.IsLeader())
+	locker.failing.Store(false)
+	time.Sleep(50 * time.Millisecond)
+	require.True(t, e.IsLeader())
+
+	// ...until the lease could expire before the next renewal.
+	locker.failing.Store(true)
+	require.Eventually(t, func() bool { return !e.IsLeader() }, time.Second, time.Millisecond)
+}
@@ -0,0 +1,113 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Locker is a store of leases: locks on keys, held by an owner until they expire or are released.
+type Locker interface {
+	// Acquire acquires the lease on the key for the given TTL, or renews it if it is already held
+	// by the owner. Returns whether the owner holds the lease.
+	Acquire(ctx context.Context, key, owner string, ttl time.Duration) (bool, error)
+	// Release releases the lease on the key, if it is held by the owner.
+	Release(ctx context.Context, key, owner string) error
+}
+
+var ( //nolint:gochecknoglobals // scripts.
+	// acquireScript sets the key to the owner if it is not set, or extends its expiry if it is
+	// already set to the owner.
+	acquireScript = redis.NewScript(`
+local owner = redis.call('GET', KEYS[1])
+if owner == false then
+	redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+	return 1
+elseif owner == ARGV[1] then
+	redis.call('PEXPIRE', KEYS[1], ARGV[2])
+	return 1
+end
+return 0`)
+
+	// releaseScript deletes the key if it is set to the owner.
+	releaseScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0`)
+)
+
+// RedisLocker is a Locker backed by Redis, which can be shared by many processes.
+type RedisLocker struct {
+	client redis.Scripter
+}
+
+// NewRedisLocker creates a new Redis locker.
+func NewRedisLocker(addr string, clusterMode bool) Locker {
+	client, ok := NewRedisClient(addr, clusterMode).(redis.Scripter)
+	if !ok {
+		panic("redis client does not support scripts")
+	}
+	return NewRedisLockerFromClient(client)
+}
+
+// NewRedisLockerFromClient creates a new Redis locker with the given client.
+func NewRedisLockerFromClient(client redis.Scripter) Locker {
+	return &RedisLocker{client: client}
+}
+
+func (l *RedisLocker) Acquire(
+	ctx context.Context, key, owner string, ttl time.Duration,
+) (bool, error) {
+	acquired, err := acquireScript.Run(ctx, l.client, []string{key}, owner, ttl.Milliseconds()).
+		Int()
+	if err != nil {
+		return false, err
+	}
+	return acquired == 1, nil
+}
+
+func (l *RedisLocker) Release(ctx context.Context, key, owner string) error {
+	return releaseScript.Run(ctx, l.client, []string{key}, owner).Err()
+}
+
+// InMemoryLocker is a Locker for a single process, e.g. for tests.
+type InMemoryLocker struct {
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+// lease is a lease held by an owner until it expires.
+type lease struct {
+	owner     string
+	expiresAt time.Time
+}
+
+// NewInMemoryLocker creates a new in-memory locker.
+func NewInMemoryLocker() Locker {
+	return &InMemoryLocker{leases: make(map[string]lease)}
+}
+
+func (l *InMemoryLocker) Acquire(
+	_ context.Context, key, owner string, ttl time.Duration,
+) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := time.Now()
+	if current, ok := l.leases[key]; ok && current.owner != owner && now.Before(current.expiresAt) {
+		return false, nil
+	}
+	l.leases[key] = lease{owner: owner, expiresAt: now.Add(ttl)}
+	return true, nil
+}
+
+func (l *InMemoryLocker) Release(_ context.Context, key, owner string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if current, ok := l.leases[key]; ok && current.owner == owner {
+		delete(l.leases, key)
+	}
+	return nil
+}
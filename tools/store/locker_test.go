@@ -0,0 +1,49 @@
+package store_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/berachain/offchain-sdk/tools/store"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLockers(t *testing.T) {
+	mr := miniredis.RunT(t)
+	lockers := map[string]store.Locker{
+		"in-memory": store.NewInMemoryLocker(),
+		"redis": store.NewRedisLockerFromClient(
+			redis.NewClient(&redis.Options{Addr: mr.Addr()}),
+		),
+	}
+
+	for name, locker := range lockers {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			// Only one owner holds the lease, which it can renew.
+			acquired, err := locker.Acquire(ctx, "lease", "a", time.Minute)
+			require.NoError(t, err)
+			require.True(t, acquired)
+			acquired, err = locker.Acquire(ctx, "lease", "b", time.Minute)
+			require.NoError(t, err)
+			require.False(t, acquired)
+			acquired, err = locker.Acquire(ctx, "lease", "a", time.Minute)
+			require.NoError(t, err)
+			require.True(t, acquired)
+
+			// Only the owner releases the lease.
+			require.NoError(t, locker.Release(ctx, "lease", "b"))
+			acquired, err = locker.Acquire(ctx, "lease", "b", time.Minute)
+			require.NoError(t, err)
+			require.False(t, acquired)
+			require.NoError(t, locker.Release(ctx, "lease", "a"))
+			acquired, err = locker.Acquire(ctx, "lease", "b", time.Minute)
+			require.NoError(t, err)
+			require.True(t, acquired)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/berachain/offchain-sdk/client/eth"
 	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/telemetry"
 
 	"github.com/ethereum/go-ethereum/ethdb"
 )
@@ -18,6 +19,9 @@ type Context struct {
 	chain  eth.Client
 	logger log.Logger
 	db     ethdb.KeyValueStore
+
+	// metrics is optional; nil if the app has no metrics registered.
+	metrics telemetry.Metrics
 }
 
 // UnwrapContext unwraps the sdk context.
@@ -51,3 +55,22 @@ func (c *Context) Logger() log.Logger {
 func (c *Context) DB() ethdb.KeyValueStore {
 	return c.db
 }
+
+// Metrics returns the app's metrics, or nil if none are registered.
+func (c *Context) Metrics() telemetry.Metrics {
+	return c.metrics
+}
+
+// WithMetrics sets the app's metrics on the context.
+func (c *Context) WithMetrics(metrics telemetry.Metrics) *Context {
+	c.metrics = metrics
+	return c
+}
+
+// WithContext returns a copy of the sdk context wrapping the given context (e.g. one derived from
+// this context with a timeout).
+func (c *Context) WithContext(ctx context.Context) *Context {
+	cp := *c
+	cp.Context = ctx
+	return &cp
+}
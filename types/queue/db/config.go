@@ -0,0 +1,23 @@
+package db
+
+import "time"
+
+const (
+	defaultVisibilityTimeout = 5 * time.Minute
+	defaultLevelDBCache      = 16 // MB
+	defaultLevelDBHandles    = 16
+)
+
+// Config is the configuration for a durable, leveldb backed queue.
+type Config struct {
+	// Path is the directory of the leveldb database storing the queue.
+	Path string
+	// VisibilityTimeout is how long a received message is hidden from other receives before it is
+	// redelivered, unless it is deleted first.
+	VisibilityTimeout time.Duration
+}
+
+// Enabled returns true if the durable queue is configured.
+func (c Config) Enabled() bool {
+	return c.Path != ""
+}
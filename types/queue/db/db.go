@@ -0,0 +1,268 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/types/queue/types"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+var (
+	// itemPrefix is the key prefix for all items stored by the queue.
+	itemPrefix = []byte("queue/item/")
+	// deadPrefix is the key prefix for the items that could not be unmarshalled, which are moved
+	// out of the queue so that they are not redelivered.
+	deadPrefix = []byte("queue/dead/")
+	// nextSeqKey is the key storing the next sequence number, so that IDs are never reused.
+	nextSeqKey = []byte("queue/nextSeq")
+)
+
+// Queue is a durable FIFO queue with at-least-once delivery, backed by an ethdb.KeyValueStore.
+// Items are persisted until deleted; received items are hidden for the visibility timeout and
+// redelivered if not deleted in time. On restart, every item not yet deleted is redelivered.
+type Queue[T types.Marshallable] struct {
+	db                ethdb.KeyValueStore
+	visibilityTimeout time.Duration
+
+	mu       sync.Mutex
+	nextSeq  uint64
+	ready    []uint64             // sequence numbers of items waiting to be received, in order
+	inFlight map[uint64]time.Time // sequence numbers of received items to their redelivery time
+}
+
+// NewQueueFromConfig creates a new durable queue, opening the leveldb database in the config.
+func NewQueueFromConfig[T types.Marshallable](cfg Config) (*Queue[T], error) {
+	db, err := leveldb.New(cfg.Path, defaultLevelDBCache, defaultLevelDBHandles, "", false)
+	if err != nil {
+		return nil, err
+	}
+	return NewQueue[T](db, cfg.VisibilityTimeout)
+}
+
+// NewQueue creates a new durable queue backed by the given store, loading any items that were
+// persisted by a previous run.
+func NewQueue[T types.Marshallable](
+	db ethdb.KeyValueStore, visibilityTimeout time.Duration,
+) (*Queue[T], error) {
+	if visibilityTimeout == 0 {
+		visibilityTimeout = defaultVisibilityTimeout
+	}
+	q := &Queue[T]{
+		db:                db,
+		visibilityTimeout: visibilityTimeout,
+		inFlight:          make(map[uint64]time.Time),
+	}
+
+	// Load the next sequence number, if persisted.
+	if bz, err := db.Get(nextSeqKey); err == nil {
+		q.nextSeq = binary.BigEndian.Uint64(bz)
+	}
+
+	// Load all persisted items, which are iterated in order of their sequence numbers.
+	it := db.NewIterator(itemPrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		seq := binary.BigEndian.Uint64(it.Key()[len(itemPrefix):])
+		q.ready = append(q.ready, seq)
+		if seq >= q.nextSeq {
+			q.nextSeq = seq + 1
+		}
+	}
+	return q, it.Error()
+}
+
+// Push persists an item at the back of the queue, returning its queue message ID.
+func (q *Queue[T]) Push(item T) (string, error) {
+	bz, err := item.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// Persist the item and the next sequence number atomically.
+	seq := q.nextSeq
+	batch := q.db.NewBatch()
+	if err = batch.Put(itemKey(seq), bz); err != nil {
+		return "", err
+	}
+	if err = batch.Put(nextSeqKey, binary.BigEndian.AppendUint64(nil, seq+1)); err != nil {
+		return "", err
+	}
+	if err = batch.Write(); err != nil {
+		return "", err
+	}
+	q.nextSeq++
+	q.ready = append(q.ready, seq)
+	return strconv.FormatUint(seq, 10), nil
+}
+
+// Receive returns the item at the front of the queue, hiding it until the visibility timeout.
+// The third return value indicates if an item was received.
+func (q *Queue[T]) Receive() (string, T, bool) {
+	msgIDs, items, err := q.ReceiveMany(1)
+	if err != nil || len(items) == 0 {
+		return "", newItem[T](), false
+	}
+	return msgIDs[0], items[0], true
+}
+
+// ReceiveMany returns at most num items, hiding them until the visibility timeout. Items whose
+// visibility timeout has expired are redelivered before any new items. Items that fail to load are
+// not returned: those that cannot be unmarshalled are moved to the dead-letter prefix, and those
+// that cannot be read are no longer tracked (until reloaded on restart). The items that loaded are
+// returned along with the errors of those that did not.
+func (q *Queue[T]) ReceiveMany(num int32) ([]string, []T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var (
+		now  = time.Now()
+		seqs = q.expired(now, int(num))
+	)
+	for len(seqs) < int(num) && len(q.ready) > 0 {
+		seqs = append(seqs, q.ready[0])
+		q.ready = q.ready[1:]
+	}
+
+	msgIDs := make([]string, 0, len(seqs))
+	items := make([]T, 0, len(seqs))
+	var errs error
+	for _, seq := range seqs {
+		delete(q.inFlight, seq)
+		bz, err := q.db.Get(itemKey(seq))
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to read queue item %d: %w", seq, err))
+			continue
+		}
+		item := newItem[T]()
+		if err = item.Unmarshal(bz); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to unmarshal queue item %d: %w", seq, err))
+			if err = q.deadLetter(seq, bz); err != nil {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+		q.inFlight[seq] = now.Add(q.visibilityTimeout)
+		msgIDs = append(msgIDs, strconv.FormatUint(seq, 10))
+		items = append(items, item)
+	}
+	return msgIDs, items, errs
+}
+
+// Delete removes the item with the given queue message ID, marking it as processed, whether it
+// is in flight or waiting to be received (e.g. after being released).
+func (q *Queue[T]) Delete(messageID string) error {
+	seq, err := strconv.ParseUint(messageID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err = q.db.Delete(itemKey(seq)); err != nil {
+		return err
+	}
+	delete(q.inFlight, seq)
+	q.ready = slices.DeleteFunc(q.ready, func(s uint64) bool { return s == seq })
+	return nil
+}
+
+// Release returns the received item with the given queue message ID to the front of the queue,
+// so that it is redelivered without waiting for its visibility timeout.
+func (q *Queue[T]) Release(messageID string) error {
+	seq, err := strconv.ParseUint(messageID, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if _, ok := q.inFlight[seq]; !ok {
+		return errors.New("queue item is not in flight: " + messageID)
+	}
+	delete(q.inFlight, seq)
+	q.ready = append([]uint64{seq}, q.ready...)
+	return nil
+}
+
+// Close closes the backing store.
+func (q *Queue[T]) Close() error {
+	return q.db.Close()
+}
+
+// Len returns the number of items waiting to be received, including the in-flight items whose
+// visibility timeout has passed (which are redelivered).
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	n, now := len(q.ready), time.Now()
+	for _, deadline := range q.inFlight {
+		if now.After(deadline) {
+			n++
+		}
+	}
+	return n
+}
+
+// expired returns, in order, at most num in-flight items whose visibility timeout has passed.
+// NOTE: must be called while holding the lock.
+func (q *Queue[T]) expired(now time.Time, num int) []uint64 {
+	var seqs []uint64
+	for seq, deadline := range q.inFlight {
+		if now.After(deadline) {
+			seqs = append(seqs, seq)
+		}
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	if len(seqs) > num {
+		seqs = seqs[:num]
+	}
+	return seqs
+}
+
+// deadLetter atomically moves the item with the given sequence number and contents to the
+// dead-letter prefix, where it is kept for inspection but never redelivered.
+// NOTE: must be called while holding the lock.
+func (q *Queue[T]) deadLetter(seq uint64, bz []byte) error {
+	batch := q.db.NewBatch()
+	if err := batch.Put(deadKey(seq), bz); err != nil {
+		return err
+	}
+	if err := batch.Delete(itemKey(seq)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// itemKey returns the key of the item with the given sequence number. Big endian encoding ensures
+// that items are iterated in order.
+func itemKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, itemPrefix...), seq)
+}
+
+// deadKey returns the dead-letter key of the item with the given sequence number.
+func deadKey(seq uint64) []byte {
+	return binary.BigEndian.AppendUint64(append([]byte{}, deadPrefix...), seq)
+}
+
+// newItem returns a new, empty instance of the item type T, which must be a pointer type.
+func newItem[T types.Marshallable]() T {
+	var t T
+	item, _ := reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
+	return item
+}
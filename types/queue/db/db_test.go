@@ -0,0 +1,126 @@
+package db_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/types/queue/db"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+type item struct {
+	Value int
+}
+
+func (i *item) String() string              { return "item" }
+func (*item) New() types.Marshallable       { return &item{} }
+func (i *item) Marshal() ([]byte, error)    { return json.Marshal(i) }
+func (i *item) Unmarshal(data []byte) error { return json.Unmarshal(data, i) }
+
+func TestRedeliveryAfterVisibilityTimeout(t *testing.T) {
+	q, err := db.NewQueue[*item](memorydb.New(), 10*time.Millisecond)
+	require.NoError(t, err)
+
+	_, err = q.Push(&item{Value: 1})
+	require.NoError(t, err)
+
+	id, received, ok := q.Receive()
+	require.True(t, ok)
+	require.Equal(t, 1, received.Value)
+
+	// Hidden until the visibility timeout passes, then counted as waiting again.
+	_, _, ok = q.Receive()
+	require.False(t, ok)
+	require.Zero(t, q.Len())
+
+	time.Sleep(20 * time.Millisecond)
+	require.Equal(t, 1, q.Len())
+	redeliveredID, redelivered, ok := q.Receive()
+	require.True(t, ok)
+	require.Equal(t, id, redeliveredID)
+	require.Equal(t, 1, redelivered.Value)
+
+	// Once deleted, it is never redelivered.
+	require.NoError(t, q.Delete(redeliveredID))
+	time.Sleep(20 * time.Millisecond)
+	_, _, ok = q.Receive()
+	require.False(t, ok)
+}
+
+func TestDeleteReleasedItem(t *testing.T) {
+	q, err := db.NewQueue[*item](memorydb.New(), time.Minute)
+	require.NoError(t, err)
+
+	_, err = q.Push(&item{Value: 1})
+	require.NoError(t, err)
+	id, _, ok := q.Receive()
+	require.True(t, ok)
+
+	// Deleting a released item removes it from the items waiting to be received.
+	require.NoError(t, q.Release(id))
+	require.Equal(t, 1, q.Len())
+	require.NoError(t, q.Delete(id))
+	require.Zero(t, q.Len())
+	_, _, ok = q.Receive()
+	require.False(t, ok)
+}
+
+func TestSurvivesRestart(t *testing.T) {
+	store := memorydb.New()
+	q, err := db.NewQueue[*item](store, time.Minute)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = q.Push(&item{Value: i})
+		require.NoError(t, err)
+	}
+	ids, _, err := q.ReceiveMany(2)
+	require.NoError(t, err)
+	require.NoError(t, q.Delete(ids[0]))
+
+	// Simulate a crash: the undeleted in-flight item and the queued item are both restored.
+	restarted, err := db.NewQueue[*item](store, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 2, restarted.Len())
+
+	_, items, err := restarted.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, 1, items[0].Value)
+	require.Equal(t, 2, items[1].Value)
+
+	// New items do not reuse the IDs of persisted items.
+	newID, err := restarted.Push(&item{Value: 3})
+	require.NoError(t, err)
+	require.NotContains(t, ids, newID)
+}
+
+func TestReceiveManySkipsUnreadableItems(t *testing.T) {
+	store := memorydb.New()
+	q, err := db.NewQueue[*item](store, time.Minute)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = q.Push(&item{Value: i})
+		require.NoError(t, err)
+	}
+	// Corrupt the second item.
+	require.NoError(t, store.Put(binary.BigEndian.AppendUint64([]byte("queue/item/"), 1), []byte("{")))
+
+	// The items that loaded are returned with the error of the one that did not.
+	ids, items, err := q.ReceiveMany(3)
+	require.Error(t, err)
+	require.Equal(t, []string{"0", "2"}, ids)
+	require.Equal(t, []*item{{Value: 0}, {Value: 2}}, items)
+
+	// The unreadable item is moved out of the queue, so it is not redelivered after a restart.
+	restarted, err := db.NewQueue[*item](store, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, 2, restarted.Len())
+	require.NoError(t, restarted.Close())
+}
@@ -0,0 +1,3 @@
+// package db provides a durable, ethdb.KeyValueStore backed implementation of a queue data
+// structure.
+package db
@@ -0,0 +1,190 @@
+package mem
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/berachain/go-utils/utils"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+)
+
+// defaultVisibilityTimeout is the default visibility timeout of received items.
+const defaultVisibilityTimeout = 30 * time.Second
+
+// received is an item that has been received but not yet deleted or released.
+type received[T types.Marshallable] struct {
+	msgID string
+	item  T
+	// deadline is when the item is redelivered, unless deleted or released first.
+	deadline time.Time
+}
+
+// QueueV2 adapts the in-memory Queue to the context-aware types.QueueV2 interface. Received items
+// are held until deleted, so that they can be released back to the queue; items that are neither
+// deleted nor released are redelivered once their visibility timeout expires.
+type QueueV2[T types.Marshallable] struct {
+	q                 *Queue[T]
+	visibilityTimeout time.Duration
+
+	mu       sync.Mutex
+	received *list.List                 // of *received[T], in order of deadline
+	byMsgID  map[string][]*list.Element // msg IDs of in-memory items need not be unique
+}
+
+// NewQueueV2 creates a new in-memory queue implementing types.QueueV2, whose received items are
+// redelivered after the given visibility timeout (defaults to 30s if 0).
+func NewQueueV2[T types.Marshallable](visibilityTimeout time.Duration) *QueueV2[T] {
+	return AdaptQueue(NewQueue[T](), visibilityTimeout)
+}
+
+// AdaptQueue adapts the given in-memory queue to the types.QueueV2 interface, redelivering
+// received items after the given visibility timeout (defaults to 30s if 0).
+func AdaptQueue[T types.Marshallable](q *Queue[T], visibilityTimeout time.Duration) *QueueV2[T] {
+	if visibilityTimeout == 0 {
+		visibilityTimeout = defaultVisibilityTimeout
+	}
+	return &QueueV2[T]{
+		q:                 q,
+		visibilityTimeout: visibilityTimeout,
+		received:          list.New(),
+		byMsgID:           make(map[string][]*list.Element),
+	}
+}
+
+// Push adds a value to the back of the queue.
+func (a *QueueV2[T]) Push(ctx context.Context, item T) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return a.q.Push(item)
+}
+
+// Receive returns the value at the front of the queue, or types.ErrEmpty if there is none.
+func (a *QueueV2[T]) Receive(ctx context.Context) (string, T, error) {
+	msgIDs, items, err := a.ReceiveMany(ctx, 1)
+	if err != nil {
+		return "", zeroValueOf[T](), err
+	}
+	if len(items) == 0 {
+		return "", zeroValueOf[T](), types.ErrEmpty
+	}
+	return msgIDs[0], items[0], nil
+}
+
+// ReceiveMany returns at most num values: the received values whose visibility timeout has
+// expired first, then those from the front of the queue.
+func (a *QueueV2[T]) ReceiveMany(ctx context.Context, num int32) ([]string, []T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var (
+		now    = time.Now()
+		msgIDs []string
+		items  []T
+	)
+	for int32(len(items)) < num {
+		oldest := a.received.Front()
+		if oldest == nil {
+			break
+		}
+		r := utils.MustGetAs[*received[T]](oldest.Value)
+		if now.Before(r.deadline) {
+			break
+		}
+		r.deadline = now.Add(a.visibilityTimeout)
+		a.received.MoveToBack(oldest)
+		msgIDs = append(msgIDs, r.msgID)
+		items = append(items, r.item)
+	}
+
+	newMsgIDs, newItems, err := a.q.ReceiveMany(num - int32(len(items)))
+	if err != nil {
+		return msgIDs, items, err
+	}
+	for i, msgID := range newMsgIDs {
+		a.hold(msgID, newItems[i], now.Add(a.visibilityTimeout))
+	}
+	return append(msgIDs, newMsgIDs...), append(items, newItems...), nil
+}
+
+// Delete drops the received value with the given message ID, so it is no longer redelivered.
+func (a *QueueV2[T]) Delete(ctx context.Context, msgID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.take(msgID)
+	return nil
+}
+
+// Release returns the received value with the given message ID to the back of the queue.
+func (a *QueueV2[T]) Release(ctx context.Context, msgID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	r := a.take(msgID)
+	a.mu.Unlock()
+	if r == nil {
+		return errors.New("queue item is not received: " + msgID)
+	}
+
+	_, err := a.q.Push(r.item)
+	return err
+}
+
+// Len returns the number of elements currently in the queue.
+func (a *QueueV2[T]) Len(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return a.q.Len(), nil
+}
+
+// hold holds the received value for release, or redelivery at the given deadline.
+// NOTE: must be called while holding the lock.
+func (a *QueueV2[T]) hold(msgID string, item T, deadline time.Time) {
+	a.byMsgID[msgID] = append(
+		a.byMsgID[msgID], a.received.PushBack(&received[T]{msgID, item, deadline}),
+	)
+}
+
+// take removes and returns the oldest held value with the given message ID, or nil if none.
+// NOTE: must be called while holding the lock.
+func (a *QueueV2[T]) take(msgID string) *received[T] {
+	elements := a.byMsgID[msgID]
+	if len(elements) == 0 {
+		return nil
+	}
+	r := utils.MustGetAs[*received[T]](elements[0].Value)
+	a.removeElement(msgID, elements[0])
+	return r
+}
+
+// removeElement removes the given held element with the given message ID.
+// NOTE: must be called while holding the lock.
+func (a *QueueV2[T]) removeElement(msgID string, element *list.Element) {
+	a.received.Remove(element)
+	elements := a.byMsgID[msgID]
+	for i, e := range elements {
+		if e == element {
+			elements = append(elements[:i], elements[i+1:]...)
+			break
+		}
+	}
+	if len(elements) == 0 {
+		delete(a.byMsgID, msgID)
+	} else {
+		a.byMsgID[msgID] = elements
+	}
+}
@@ -0,0 +1,78 @@
+package mem_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/types/queue/mem"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	ID string
+}
+
+func (i *item) String() string         { return i.ID }
+func (*item) New() types.Marshallable  { return &item{} }
+func (*item) Marshal() ([]byte, error) { return nil, nil }
+func (*item) Unmarshal(_ []byte) error { return nil }
+
+func TestReleaseAndDelete(t *testing.T) {
+	ctx := context.Background()
+	q := mem.NewQueueV2[*item](0)
+
+	_, _, err := q.Receive(ctx)
+	require.ErrorIs(t, err, types.ErrEmpty)
+
+	_, err = q.Push(ctx, &item{ID: "a"})
+	require.NoError(t, err)
+	_, err = q.Push(ctx, &item{ID: "b"})
+	require.NoError(t, err)
+
+	// A released item is returned to the back of the queue.
+	msgID, _, err := q.Receive(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "a", msgID)
+	require.NoError(t, q.Release(ctx, msgID))
+
+	msgIDs, _, err := q.ReceiveMany(ctx, 2)
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, msgIDs)
+
+	// A deleted item can no longer be released.
+	require.NoError(t, q.Delete(ctx, "a"))
+	require.Error(t, q.Release(ctx, "a"))
+
+	// Operations fail once the context is done.
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = q.Push(cancelled, &item{ID: "c"})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestRedeliveryAfterVisibilityTimeout(t *testing.T) {
+	ctx := context.Background()
+	q := mem.NewQueueV2[*item](10 * time.Millisecond)
+
+	_, err := q.Push(ctx, &item{ID: "a"})
+	require.NoError(t, err)
+	msgID, _, err := q.Receive(ctx)
+	require.NoError(t, err)
+
+	// Hidden until the visibility timeout passes.
+	_, _, err = q.Receive(ctx)
+	require.ErrorIs(t, err, types.ErrEmpty)
+
+	time.Sleep(20 * time.Millisecond)
+	redelivered, _, err := q.Receive(ctx)
+	require.NoError(t, err)
+	require.Equal(t, msgID, redelivered)
+
+	// Once deleted, it is never redelivered.
+	require.NoError(t, q.Delete(ctx, msgID))
+	time.Sleep(20 * time.Millisecond)
+	_, _, err = q.Receive(ctx)
+	require.ErrorIs(t, err, types.ErrEmpty)
+}
@@ -0,0 +1,35 @@
+package redis
+
+import "time"
+
+const (
+	defaultGroup            = "offchain-sdk"
+	defaultClaimIdleTimeout = 5 * time.Minute
+	deadLetterSuffix        = ":dead"
+)
+
+// Config is the configuration for a redis streams backed queue.
+type Config struct {
+	// Address of the redis server (or a cluster node if ClusterMode is true).
+	Addr        string
+	ClusterMode bool
+
+	// Stream is the key of the redis stream backing the queue.
+	Stream string
+	// Group is the consumer group shared by all replicas consuming the queue.
+	Group string
+	// Consumer is the unique name of this replica in the consumer group. Defaults to the hostname
+	// and process ID.
+	Consumer string
+	// ClaimIdleTimeout is how long a received message can remain unacked (not deleted) before it is
+	// reclaimed and redelivered by a call to Receive or ReceiveMany.
+	ClaimIdleTimeout time.Duration
+	// DeadLetterStream is the key of the redis stream that entries which cannot be unmarshalled are
+	// moved to. Defaults to the stream key with a ":dead" suffix.
+	DeadLetterStream string
+}
+
+// Enabled returns true if the redis queue is configured.
+func (c Config) Enabled() bool {
+	return c.Addr != "" && c.Stream != ""
+}
@@ -0,0 +1,2 @@
+// package redis provides a redis streams backed implementation of a queue data structure.
+package redis
@@ -0,0 +1,205 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/berachain/offchain-sdk/types/queue/types"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// dataField is the field of a stream entry that holds the marshalled item.
+	dataField = "data"
+	// idField is the field of a dead-letter stream entry that holds the ID of the original entry.
+	idField = "id"
+)
+
+// Client is an interface that defines the necessary methods for interacting with redis streams.
+type Client interface {
+	XAdd(ctx context.Context, a *goredis.XAddArgs) *goredis.StringCmd
+	XReadGroup(ctx context.Context, a *goredis.XReadGroupArgs) *goredis.XStreamSliceCmd
+	XAutoClaim(ctx context.Context, a *goredis.XAutoClaimArgs) *goredis.XAutoClaimCmd
+	XAck(ctx context.Context, stream, group string, ids ...string) *goredis.IntCmd
+	XDel(ctx context.Context, stream string, ids ...string) *goredis.IntCmd
+	XLen(ctx context.Context, stream string) *goredis.IntCmd
+	XPending(ctx context.Context, stream, group string) *goredis.XPendingCmd
+	XGroupCreateMkStream(ctx context.Context, stream, group, start string) *goredis.StatusCmd
+}
+
+// Queue is a queue backed by a redis stream and consumer group. Received messages remain pending
+// in the consumer group until deleted; messages left pending for longer than the claim idle
+// timeout (e.g. if a replica crashed) are reclaimed and redelivered.
+type Queue[T types.Marshallable] struct {
+	client Client
+	cfg    Config
+}
+
+// NewQueueFromConfig creates a new redis queue, connecting to the redis server in the config.
+func NewQueueFromConfig[T types.Marshallable](cfg Config) (*Queue[T], error) {
+	var client Client
+	if cfg.ClusterMode {
+		client = goredis.NewClusterClient(&goredis.ClusterOptions{Addrs: []string{cfg.Addr}})
+	} else {
+		client = goredis.NewClient(&goredis.Options{Addr: cfg.Addr})
+	}
+	return NewQueue[T](client, cfg)
+}
+
+// NewQueue creates a new redis queue with the given client, creating the stream and consumer
+// group if they do not already exist.
+func NewQueue[T types.Marshallable](client Client, cfg Config) (*Queue[T], error) {
+	if cfg.Stream == "" {
+		return nil, errors.New("redis queue stream must be set")
+	}
+	if cfg.Group == "" {
+		cfg.Group = defaultGroup
+	}
+	if cfg.Consumer == "" {
+		hostname, _ := os.Hostname()
+		cfg.Consumer = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+	if cfg.ClaimIdleTimeout == 0 {
+		cfg.ClaimIdleTimeout = defaultClaimIdleTimeout
+	}
+	if cfg.DeadLetterStream == "" {
+		cfg.DeadLetterStream = cfg.Stream + deadLetterSuffix
+	}
+
+	// Start the group from the beginning of the stream so that no items pushed before the group
+	// was created are missed.
+	err := client.XGroupCreateMkStream(context.TODO(), cfg.Stream, cfg.Group, "0").Err()
+	if err != nil && !strings.Contains(err.Error(), "BUSYGROUP") {
+		return nil, err
+	}
+
+	return &Queue[T]{client: client, cfg: cfg}, nil
+}
+
+// Push adds an item to the redis stream, returning the ID of the stream entry.
+func (q *Queue[T]) Push(item T) (string, error) {
+	bz, err := item.Marshal()
+	if err != nil {
+		return "", err
+	}
+
+	return q.client.XAdd(context.TODO(), &goredis.XAddArgs{
+		Stream: q.cfg.Stream,
+		Values: map[string]any{dataField: string(bz)},
+	}).Result()
+}
+
+// Receive claims a single item from the queue. The second return value indicates if an item was
+// received.
+func (q *Queue[T]) Receive() (string, T, bool) {
+	msgIDs, items, err := q.ReceiveMany(1)
+	if err != nil || len(items) == 0 {
+		return "", newItem[T](), false
+	}
+	return msgIDs[0], items[0], true
+}
+
+// ReceiveMany claims at most num items from the queue. Items pending for longer than the claim
+// idle timeout are reclaimed first, before any new items are read. Entries that cannot be
+// unmarshalled are moved to the dead-letter stream; the items that were unmarshalled are returned
+// along with the errors of those that were not.
+func (q *Queue[T]) ReceiveMany(num int32) ([]string, []T, error) {
+	ctx := context.TODO()
+
+	// Reclaim any messages that were received but never deleted in time.
+	msgs, _, err := q.client.XAutoClaim(ctx, &goredis.XAutoClaimArgs{
+		Stream:   q.cfg.Stream,
+		Group:    q.cfg.Group,
+		Consumer: q.cfg.Consumer,
+		MinIdle:  q.cfg.ClaimIdleTimeout,
+		Start:    "0-0",
+		Count:    int64(num),
+	}).Result()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Read new messages to fill the remainder of the batch.
+	if remaining := int64(num) - int64(len(msgs)); remaining > 0 {
+		var streams []goredis.XStream
+		streams, err = q.client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+			Group:    q.cfg.Group,
+			Consumer: q.cfg.Consumer,
+			Streams:  []string{q.cfg.Stream, ">"},
+			Count:    remaining,
+			Block:    -1, // do not block if the stream is empty
+		}).Result()
+		if err != nil && !errors.Is(err, goredis.Nil) {
+			return nil, nil, err
+		}
+		for _, stream := range streams {
+			msgs = append(msgs, stream.Messages...)
+		}
+	}
+
+	msgIDs := make([]string, 0, len(msgs))
+	items := make([]T, 0, len(msgs))
+	var errs error
+	for _, msg := range msgs {
+		item := newItem[T]()
+		data, _ := msg.Values[dataField].(string)
+		if err = item.Unmarshal([]byte(data)); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to unmarshal redis entry %s: %w", msg.ID, err))
+			if err = q.deadLetter(ctx, msg); err != nil {
+				errs = errors.Join(errs, err)
+			}
+			continue
+		}
+		msgIDs = append(msgIDs, msg.ID)
+		items = append(items, item)
+	}
+	return msgIDs, items, errs
+}
+
+// deadLetter moves the given entry to the dead-letter stream, where it is kept for inspection but
+// never redelivered. If it fails, the entry remains pending and is reclaimed later.
+func (q *Queue[T]) deadLetter(ctx context.Context, msg goredis.XMessage) error {
+	err := q.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: q.cfg.DeadLetterStream,
+		Values: map[string]any{idField: msg.ID, dataField: msg.Values[dataField]},
+	}).Err()
+	if err != nil {
+		return err
+	}
+	return q.Delete(msg.ID)
+}
+
+// Delete acknowledges the message with the given ID and removes it from the stream, marking it as
+// processed.
+func (q *Queue[T]) Delete(messageID string) error {
+	ctx := context.TODO()
+	if err := q.client.XAck(ctx, q.cfg.Stream, q.cfg.Group, messageID).Err(); err != nil {
+		return err
+	}
+	return q.client.XDel(ctx, q.cfg.Stream, messageID).Err()
+}
+
+// Len returns the number of items in the stream that have not yet been received.
+func (q *Queue[T]) Len() int {
+	ctx := context.TODO()
+	total, err := q.client.XLen(ctx, q.cfg.Stream).Result()
+	if err != nil {
+		return 0
+	}
+	pending, err := q.client.XPending(ctx, q.cfg.Stream, q.cfg.Group).Result()
+	if err != nil {
+		return int(total)
+	}
+	return int(total - pending.Count)
+}
+
+// newItem returns a new, empty instance of the item type T, which must be a pointer type.
+func newItem[T types.Marshallable]() T {
+	var t T
+	item, _ := reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
+	return item
+}
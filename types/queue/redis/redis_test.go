@@ -0,0 +1,119 @@
+package redis_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/berachain/offchain-sdk/types/queue/redis"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Value int
+}
+
+func (i *item) String() string              { return "item" }
+func (*item) New() types.Marshallable       { return &item{} }
+func (i *item) Marshal() ([]byte, error)    { return json.Marshal(i) }
+func (i *item) Unmarshal(data []byte) error { return json.Unmarshal(data, i) }
+
+func newQueue(t *testing.T, mr *miniredis.Miniredis, consumer string) *redis.Queue[*item] {
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	q, err := redis.NewQueue[*item](client, redis.Config{
+		Stream:           "requests",
+		Consumer:         consumer,
+		ClaimIdleTimeout: time.Minute,
+	})
+	require.NoError(t, err)
+	return q
+}
+
+func TestPushReceiveDelete(t *testing.T) {
+	mr := miniredis.RunT(t)
+	q := newQueue(t, mr, "replica-1")
+
+	for i := 0; i < 3; i++ {
+		_, err := q.Push(&item{Value: i})
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, q.Len())
+
+	ids, items, err := q.ReceiveMany(2)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	require.Equal(t, 0, items[0].Value)
+	require.Equal(t, 1, items[1].Value)
+	require.Equal(t, 1, q.Len())
+
+	for _, id := range ids {
+		require.NoError(t, q.Delete(id))
+	}
+
+	_, last, ok := q.Receive()
+	require.True(t, ok)
+	require.Equal(t, 2, last.Value)
+
+	_, _, ok = q.Receive()
+	require.False(t, ok)
+}
+
+func TestReclaimIdleMessages(t *testing.T) {
+	mr := miniredis.RunT(t)
+	now := time.Now()
+	mr.SetTime(now)
+
+	crashed := newQueue(t, mr, "replica-1")
+	healthy := newQueue(t, mr, "replica-2")
+
+	_, err := crashed.Push(&item{Value: 42})
+	require.NoError(t, err)
+
+	// The first replica receives the message but never deletes it.
+	_, _, ok := crashed.Receive()
+	require.True(t, ok)
+
+	// The message is not redelivered before the idle timeout...
+	_, _, ok = healthy.Receive()
+	require.False(t, ok)
+
+	// ...but is reclaimed by another replica afterwards.
+	mr.SetTime(now.Add(2 * time.Minute))
+	id, reclaimed, ok := healthy.Receive()
+	require.True(t, ok)
+	require.Equal(t, 42, reclaimed.Value)
+	require.NoError(t, healthy.Delete(id))
+
+	mr.SetTime(now.Add(4 * time.Minute))
+	_, _, ok = crashed.Receive()
+	require.False(t, ok)
+}
+
+func TestReceiveManyDeadLettersUndecodableEntries(t *testing.T) {
+	mr := miniredis.RunT(t)
+	q := newQueue(t, mr, "replica-1")
+
+	_, err := q.Push(&item{Value: 0})
+	require.NoError(t, err)
+	badID, err := mr.XAdd("requests", "*", []string{"data", "{"})
+	require.NoError(t, err)
+	_, err = q.Push(&item{Value: 2})
+	require.NoError(t, err)
+
+	// The decoded items are returned with the error of the undecodable entry.
+	_, items, err := q.ReceiveMany(3)
+	require.Error(t, err)
+	require.Equal(t, []*item{{Value: 0}, {Value: 2}}, items)
+
+	// The undecodable entry is moved to the dead-letter stream.
+	dead, err := mr.Stream("requests:dead")
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	require.ElementsMatch(t, []string{"id", badID, "data", "{"}, dead[0].Values)
+	entries, err := mr.Stream("requests")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+}
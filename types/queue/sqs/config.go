@@ -1,9 +1,73 @@
 package sqs
 
+import (
+	"strings"
+	"time"
+)
+
+const (
+	defaultVisibilityTimeout = 30 * time.Second // the SQS default
+	defaultMaxInProcess      = 1000
+	defaultMaxInProcessAge   = time.Hour
+
+	// fifoSuffix is the required suffix of the name (and URL) of a FIFO queue.
+	fifoSuffix = ".fifo"
+	// maxWaitTimeSeconds is the max long polling wait time allowed by SQS.
+	maxWaitTimeSeconds = 20
+)
+
 // Refer to aws.Config for more details.
 type Config struct {
 	Region      string
 	AccessKeyID string
 	SecretKey   string
 	QueueURL    string
+	// Endpoint is a custom SQS endpoint, e.g. a local SQS-compatible stand-in for testing. If
+	// empty, the AWS endpoint for the region is used.
+	Endpoint string
+
+	// WaitTimeSeconds is how long a receive waits (long polls) for messages to arrive, at most 20
+	// seconds. If 0, receives return immediately (short polling).
+	WaitTimeSeconds int32
+
+	// VisibilityTimeout is how long a received message is hidden from other receivers. While a
+	// message is in process (received but not deleted), its visibility is periodically extended
+	// by this amount.
+	VisibilityTimeout time.Duration
+	// HeartbeatInterval is how often the visibility of in process messages is extended. Defaults
+	// to a third of the visibility timeout. A message is no longer extended once it is deleted or
+	// released, so consumers must do either as soon as its outcome is known.
+	HeartbeatInterval time.Duration
+	// MaxInProcess is the max number of messages that can be in process at once. No more messages
+	// are received while at capacity.
+	MaxInProcess int
+	// MaxInProcessAge is how long a message can be in process before its visibility is no longer
+	// extended and it is forgotten, allowing SQS to redeliver it. A message still being processed
+	// past this age may be processed twice, so it should exceed the longest processing time.
+	MaxInProcessAge time.Duration
+}
+
+// FIFO returns true if the configured queue is a FIFO queue.
+func (c Config) FIFO() bool {
+	return strings.HasSuffix(c.QueueURL, fifoSuffix)
+}
+
+// withDefaults returns the config with any unset optional fields set to their defaults.
+func (c Config) withDefaults() Config {
+	if c.VisibilityTimeout <= 0 {
+		c.VisibilityTimeout = defaultVisibilityTimeout
+	}
+	if c.HeartbeatInterval <= 0 {
+		c.HeartbeatInterval = c.VisibilityTimeout / 3 //nolint:gomnd // extend well before expiry.
+	}
+	if c.MaxInProcess <= 0 {
+		c.MaxInProcess = defaultMaxInProcess
+	}
+	if c.WaitTimeSeconds > maxWaitTimeSeconds {
+		c.WaitTimeSeconds = maxWaitTimeSeconds
+	}
+	if c.MaxInProcessAge <= 0 {
+		c.MaxInProcessAge = defaultMaxInProcessAge
+	}
+	return c
 }
@@ -0,0 +1,28 @@
+package sqs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// defaultMessageGroupID is the message group of items that do not implement FIFOMessage.
+const defaultMessageGroupID = "default"
+
+// FIFOMessage is an (optional) interface for items pushed to a FIFO queue, which determines the
+// message group (messages in a group are received strictly in order) and the deduplication ID
+// (messages with the same ID sent within 5 minutes are only delivered once).
+type FIFOMessage interface {
+	MessageGroupID() string
+	DeduplicationID() string
+}
+
+// fifoIDs returns the message group and deduplication ID of the item with the given marshalled
+// body. Items that do not implement FIFOMessage are all sent to the same group and deduplicated
+// by their content.
+func fifoIDs(item any, body []byte) (string, string) {
+	if msg, ok := item.(FIFOMessage); ok {
+		return msg.MessageGroupID(), msg.DeduplicationID()
+	}
+	hash := sha256.Sum256(body)
+	return defaultMessageGroupID, hex.EncodeToString(hash[:])
+}
@@ -2,14 +2,19 @@ package sqs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
 	"strconv"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/sqs"
 	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/berachain/offchain-sdk/log"
 	awsutils "github.com/berachain/offchain-sdk/types/aws"
 	"github.com/berachain/offchain-sdk/types/queue/types"
 )
@@ -24,31 +29,47 @@ type Client interface {
 		params *sqs.SendMessageInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageOutput, error)
 	ReceiveMessage(ctx context.Context,
 		params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput,
+		optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
 	DeleteMessage(ctx context.Context,
 		params *sqs.DeleteMessageInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageOutput, error)
+	DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput,
+		optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+	ChangeMessageVisibility(ctx context.Context, params *sqs.ChangeMessageVisibilityInput,
+		optFns ...func(*sqs.Options)) (*sqs.ChangeMessageVisibilityOutput, error)
 	GetQueueAttributes(ctx context.Context, params *sqs.GetQueueAttributesInput,
 		optFns ...func(*sqs.Options)) (*sqs.GetQueueAttributesOutput, error)
 }
 
-// Queue is a wrapper struct around the SQS API.
+// inProcessMsg is a message that has been received but not yet deleted.
+type inProcessMsg struct {
+	receiptHandle string
+	receivedAt    time.Time
+}
+
+// Queue is a wrapper struct around the SQS API. The visibility of received messages is extended
+// (heartbeated) until they are deleted, so that SQS does not redeliver messages that are still
+// being processed.
 type Queue[T types.Marshallable] struct {
 	svc      Client
 	queueURL string
+	cfg      Config
+	logger   log.Logger
 
 	inProcessMu *sync.RWMutex
-	inProcess   map[string]string
+	inProcess   map[string]*inProcessMsg
+	// reserved is the in process capacity reserved by the receives in progress.
+	reserved int
+
+	stop chan struct{}
+	once sync.Once
 }
 
 // NewQueueFromAWSConfig creates a new SQS object with the specified AWS config & queue URL.
 func NewQueueFromAWSConfig[T types.Marshallable](
 	cfg aws.Config, queueURL string,
 ) (*Queue[T], error) {
-	return &Queue[T]{
-		svc:         sqs.NewFromConfig(cfg),
-		queueURL:    queueURL,
-		inProcessMu: new(sync.RWMutex),
-		inProcess:   make(map[string]string),
-	}, nil
+	return NewQueue[T](sqs.NewFromConfig(cfg), Config{QueueURL: queueURL})
 }
 
 // NewQueueFromConfig creates a new SQS object with the specified config & queue URL.
@@ -63,11 +84,57 @@ func NewQueueFromConfig[T types.Marshallable](cfg Config) (*Queue[T], error) {
 			return nil
 		})
 
-	return NewQueueFromAWSConfig[T](awsCfg, cfg.QueueURL)
+	return NewQueue[T](sqs.NewFromConfig(awsCfg, func(o *sqs.Options) {
+		// Use the custom endpoint, if set.
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = aws.String(cfg.Endpoint)
+		}
+	}), cfg)
+}
+
+// NewQueue creates a new SQS object with the specified client & config, and starts extending the
+// visibility of in process messages. Close must be called to stop.
+func NewQueue[T types.Marshallable](svc Client, cfg Config) (*Queue[T], error) {
+	if cfg.QueueURL == "" {
+		return nil, errors.New("sqs queue URL must be set")
+	}
+	cfg = cfg.withDefaults()
+
+	q := &Queue[T]{
+		svc:         svc,
+		queueURL:    cfg.QueueURL,
+		cfg:         cfg,
+		logger:      log.NewBlankLogger(io.Discard),
+		inProcessMu: new(sync.RWMutex),
+		inProcess:   make(map[string]*inProcessMsg),
+		stop:        make(chan struct{}),
+	}
+	go q.heartbeatLoop()
+	return q, nil
+}
+
+// SetLogger sets the logger used to report messages that are no longer heartbeated. Logs are
+// discarded by default.
+func (q *Queue[T]) SetLogger(logger log.Logger) {
+	q.inProcessMu.Lock()
+	defer q.inProcessMu.Unlock()
+	q.logger = logger
+}
+
+// Close stops extending the visibility of in process messages, which will be redelivered by SQS
+// once their visibility timeout expires.
+func (q *Queue[T]) Close() error {
+	q.once.Do(func() { close(q.stop) })
+	return nil
 }
 
-// Push adds an item to the SQS queue.
+// Push adds an item to the SQS queue. If the queue is a FIFO queue, the item's message group and
+// deduplication ID are set (see FIFOMessage).
 func (q *Queue[T]) Push(item T) (string, error) {
+	return q.push(context.TODO(), item)
+}
+
+func (q *Queue[T]) push(ctx context.Context, item T) (string, error) {
 	// Marshal the item
 	bz, err := item.Marshal()
 	if err != nil {
@@ -76,121 +143,276 @@ func (q *Queue[T]) Push(item T) (string, error) {
 
 	// Send the message to the SQS queue with the provided context
 	str := string(bz)
-	output, err := q.svc.SendMessage(context.TODO(), &sqs.SendMessageInput{
+	input := &sqs.SendMessageInput{
 		QueueUrl:    &q.queueURL,
 		MessageBody: &str,
-	})
+	}
+	if q.cfg.FIFO() {
+		groupID, dedupID := fifoIDs(item, bz)
+		input.MessageGroupId, input.MessageDeduplicationId = &groupID, &dedupID
+	}
+	output, err := q.svc.SendMessage(ctx, input)
 	if err != nil || output == nil || output.MessageId == nil {
 		return "", err
 	}
 	return *output.MessageId, nil
 }
 
-// Pop retrieves an item from the SQS queue.
-func (q *Queue[T]) Receive() (string, T, bool) {
-	var t2 T
-	t1 := reflect.TypeOf(t2).Elem()
-	newInstance := reflect.New(t1).Interface()
-	t, _ := newInstance.(T)
+// PushMany adds the items to the SQS queue in batches, returning the message ID of each item in
+// order. If some items fail to send, their message IDs are empty and the errors are returned.
+func (q *Queue[T]) PushMany(items []T) ([]string, error) {
+	return q.pushMany(context.TODO(), items)
+}
 
-	// Receive a message from the SQS queue
-	resp, err := q.svc.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
-		QueueUrl:            &q.queueURL,
-		MaxNumberOfMessages: 1,
-	})
-	if err != nil {
-		return "", t, false
+func (q *Queue[T]) pushMany(ctx context.Context, items []T) ([]string, error) {
+	msgIDs := make([]string, len(items))
+	var errs error
+	for start := 0; start < len(items); start += awsMaxBatchSize {
+		end := min(start+awsMaxBatchSize, len(items))
+		if err := q.pushBatch(ctx, items[start:end], msgIDs[start:end]); err != nil {
+			errs = errors.Join(errs, err)
+		}
 	}
+	return msgIDs, errs
+}
 
-	// Check if a message was received
-	if len(resp.Messages) == 0 {
-		return "", t, false
+// pushBatch sends at most awsMaxBatchSize items in one batch, filling in the message ID of each
+// successfully sent item.
+func (q *Queue[T]) pushBatch(ctx context.Context, items []T, msgIDs []string) error {
+	entries := make([]sqstypes.SendMessageBatchRequestEntry, len(items))
+	for i, item := range items {
+		bz, err := item.Marshal()
+		if err != nil {
+			return err
+		}
+
+		str := string(bz)
+		entries[i] = sqstypes.SendMessageBatchRequestEntry{
+			Id:          aws.String(strconv.Itoa(i)),
+			MessageBody: &str,
+		}
+		if q.cfg.FIFO() {
+			groupID, dedupID := fifoIDs(item, bz)
+			entries[i].MessageGroupId, entries[i].MessageDeduplicationId = &groupID, &dedupID
+		}
 	}
 
-	// Unmarshal the message into a new instance of type T
-	if err = t.Unmarshal([]byte(*resp.Messages[0].Body)); err != nil {
-		return "", t, false
+	output, err := q.svc.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+		QueueUrl: &q.queueURL,
+		Entries:  entries,
+	})
+	if err != nil {
+		return err
 	}
+	for _, entry := range output.Successful {
+		i, _ := strconv.Atoi(aws.ToString(entry.Id))
+		msgIDs[i] = aws.ToString(entry.MessageId)
+	}
+	return batchErrors(output.Failed)
+}
 
-	// Add to the inProcess MessageID queue, mark the Message as in Process.
-	// TODO memory growth atm.
-	q.inProcess[*resp.Messages[0].MessageId] = *resp.Messages[0].ReceiptHandle
-	return *resp.Messages[0].MessageId, t, true
+// Pop retrieves an item from the SQS queue.
+func (q *Queue[T]) Receive() (string, T, bool) {
+	msgIDs, ts, err := q.ReceiveMany(1)
+	if err != nil || len(ts) == 0 {
+		return "", newItem[T](), false
+	}
+	return msgIDs[0], ts[0], true
 }
 
+// ReceiveMany retrieves at most num items from the SQS queue. No items are received if the max
+// number of messages are already in process. Messages redelivered by SQS while still in process
+// by this queue are not returned again; only their receipt handle is updated.
+//
+// Messages that fail to unmarshal (poison messages) are skipped one at a time: they are not put in
+// process, so SQS redelivers them once their visibility timeout expires (and moves them to the
+// dead-letter queue, if a redrive policy is configured). The items that were unmarshalled are
+// returned along with the errors of those that were not.
 func (q *Queue[T]) ReceiveMany(num int32) ([]string, []T, error) {
+	return q.receiveMany(context.TODO(), num)
+}
+
+func (q *Queue[T]) receiveMany(ctx context.Context, num int32) ([]string, []T, error) {
 	if num > awsMaxBatchSize {
 		num = awsMaxBatchSize
 	}
 
+	// Bound the number of in process messages, reserving the capacity for this receive so that
+	// concurrent receives cannot overshoot the bound.
+	q.inProcessMu.Lock()
+	capacity := q.cfg.MaxInProcess - len(q.inProcess) - q.reserved
+	if capacity <= 0 {
+		q.inProcessMu.Unlock()
+		return nil, nil, nil
+	}
+	if int(num) > capacity {
+		num = int32(capacity)
+	}
+	q.reserved += int(num)
+	q.inProcessMu.Unlock()
+	defer func() {
+		q.inProcessMu.Lock()
+		q.reserved -= int(num)
+		q.inProcessMu.Unlock()
+	}()
+
 	// Receive a message from the SQS queue
-	resp, err := q.svc.ReceiveMessage(context.TODO(), &sqs.ReceiveMessageInput{
+	resp, err := q.svc.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
 		QueueUrl:            &q.queueURL,
 		MaxNumberOfMessages: num,
+		VisibilityTimeout:   int32(q.cfg.VisibilityTimeout.Seconds()),
+		WaitTimeSeconds:     q.cfg.WaitTimeSeconds,
+		AttributeNames: []sqstypes.QueueAttributeName{
+			sqstypes.QueueAttributeName(sqstypes.MessageSystemAttributeNameApproximateReceiveCount),
+		},
 	})
 	if err != nil {
 		return nil, nil, err
 	}
 	// Check if a message was received
 	if len(resp.Messages) == 0 {
-		return nil, nil, err
+		return nil, nil, nil
 	}
 
-	msgIDs := make([]string, len(resp.Messages))
-	ts := make([]T, len(resp.Messages))
+	msgIDs := make([]string, 0, len(resp.Messages))
+	ts := make([]T, 0, len(resp.Messages))
+	var errs error
 
-	for i, m := range resp.Messages {
-		var t2 T
-		t1 := reflect.TypeOf(t2).Elem()
-		newInstance := reflect.New(t1).Interface()
-		t, _ := newInstance.(T)
+	q.inProcessMu.Lock()
+	defer q.inProcessMu.Unlock()
+	for _, m := range resp.Messages {
+		// If redelivered while still in process (e.g. the heartbeat was late), only the latest
+		// receipt handle is kept, so the message is not processed twice.
+		if ipm, ok := q.inProcess[*m.MessageId]; ok && isRedelivery(m) {
+			ipm.receiptHandle = *m.ReceiptHandle
+			continue
+		}
 
 		// Unmarshal the message into a new instance of type T
+		t := newItem[T]()
 		if err = t.Unmarshal([]byte(*m.Body)); err != nil {
-			return nil, nil, err
+			errs = errors.Join(errs, fmt.Errorf("failed to unmarshal sqs message %s: %w",
+				aws.ToString(m.MessageId), err))
+			continue
 		}
 
 		// Add to the inProcess MessageID queue, mark the Message as in Process.
-		// TODO memory growth atm.
-		q.inProcessMu.Lock()
-		q.inProcess[*m.MessageId] = *m.ReceiptHandle
-		q.inProcessMu.Unlock()
+		q.inProcess[*m.MessageId] = &inProcessMsg{
+			receiptHandle: *m.ReceiptHandle,
+			receivedAt:    time.Now(),
+		}
 
-		msgIDs[i] = *m.MessageId
-		ts[i] = t
+		msgIDs = append(msgIDs, *m.MessageId)
+		ts = append(ts, t)
 	}
 
-	return msgIDs, ts, nil
+	return msgIDs, ts, errs
 }
 
 func (q *Queue[T]) Len() int {
-	// Delete from the queue to mark as complete.
-	resp, err := q.svc.GetQueueAttributes(context.TODO(), &sqs.GetQueueAttributesInput{
+	val, _ := q.length(context.TODO())
+	return val
+}
+
+func (q *Queue[T]) length(ctx context.Context) (int, error) {
+	resp, err := q.svc.GetQueueAttributes(ctx, &sqs.GetQueueAttributesInput{
 		QueueUrl: &q.queueURL,
 		AttributeNames: []sqstypes.QueueAttributeName{
 			"ApproximateNumberOfMessages",
 		},
 	})
 	if err != nil {
-		return 0
+		return 0, err
 	}
 	anm := resp.Attributes["ApproximateNumberOfMessages"]
-	val, _ := strconv.ParseInt(anm, 10, 64)
-	return int(val)
+	val, err := strconv.ParseInt(anm, 10, 64)
+	return int(val), err
 }
 
 func (q *Queue[T]) Delete(messageID string) error {
-	return q.deleteMessage(messageID)
+	return q.deleteMessage(context.TODO(), messageID)
+}
+
+// DeleteMany deletes the messages with the given IDs in batches, marking them as complete.
+func (q *Queue[T]) DeleteMany(messageIDs ...string) error {
+	return q.deleteMany(context.TODO(), messageIDs...)
+}
+
+func (q *Queue[T]) deleteMany(ctx context.Context, messageIDs ...string) error {
+	var errs error
+	for start := 0; start < len(messageIDs); start += awsMaxBatchSize {
+		end := min(start+awsMaxBatchSize, len(messageIDs))
+		if err := q.deleteBatch(ctx, messageIDs[start:end]); err != nil {
+			errs = errors.Join(errs, err)
+		}
+	}
+	return errs
+}
+
+// deleteBatch deletes at most awsMaxBatchSize messages in one batch.
+func (q *Queue[T]) deleteBatch(ctx context.Context, messageIDs []string) error {
+	var (
+		errs    error
+		entries = make([]sqstypes.DeleteMessageBatchRequestEntry, 0, len(messageIDs))
+	)
+
+	// Grab the latest receipt handles by the messageIDs, using the messageIDs as the entry IDs.
+	q.inProcessMu.RLock()
+	for _, messageID := range messageIDs {
+		ipm, ok := q.inProcess[messageID]
+		if !ok {
+			errs = errors.Join(errs, errors.New("sqs message is not in process: "+messageID))
+			continue
+		}
+		entries = append(entries, sqstypes.DeleteMessageBatchRequestEntry{
+			Id:            aws.String(messageID),
+			ReceiptHandle: aws.String(ipm.receiptHandle),
+		})
+	}
+	q.inProcessMu.RUnlock()
+	if len(entries) == 0 {
+		return errs
+	}
+
+	output, err := q.svc.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+		QueueUrl: &q.queueURL,
+		Entries:  entries,
+	})
+	if err != nil {
+		return errors.Join(errs, err)
+	}
+
+	// remove the deleted messageIDs from the inProcess map.
+	q.inProcessMu.Lock()
+	for _, entry := range output.Successful {
+		delete(q.inProcess, aws.ToString(entry.Id))
+	}
+	q.inProcessMu.Unlock()
+
+	return errors.Join(errs, batchErrors(output.Failed))
+}
+
+// InProcess returns the number of messages received but not yet deleted.
+func (q *Queue[T]) InProcess() int {
+	q.inProcessMu.RLock()
+	defer q.inProcessMu.RUnlock()
+
+	return len(q.inProcess)
 }
 
-func (q *Queue[T]) deleteMessage(messageID string) error {
+func (q *Queue[T]) deleteMessage(ctx context.Context, messageID string) error {
 	// Grab the latest receipt handle by the messageID.
 	q.inProcessMu.RLock()
-	receiptHandle := q.inProcess[messageID]
+	ipm, ok := q.inProcess[messageID]
+	if !ok {
+		q.inProcessMu.RUnlock()
+		return errors.New("sqs message is not in process: " + messageID)
+	}
+	receiptHandle := ipm.receiptHandle
 	q.inProcessMu.RUnlock()
 
 	// Delete from the queue to mark as complete.
-	_, err := q.svc.DeleteMessage(context.TODO(), &sqs.DeleteMessageInput{
+	_, err := q.svc.DeleteMessage(ctx, &sqs.DeleteMessageInput{
 		QueueUrl:      &q.queueURL,
 		ReceiptHandle: &receiptHandle,
 	})
@@ -205,3 +427,103 @@ func (q *Queue[T]) deleteMessage(messageID string) error {
 
 	return nil
 }
+
+// release makes the in process message with the given ID visible again immediately, so that it
+// is redelivered without waiting for its visibility timeout.
+func (q *Queue[T]) release(ctx context.Context, messageID string) error {
+	// Stop tracking the message first, so that it is no longer heartbeated.
+	q.inProcessMu.Lock()
+	ipm, ok := q.inProcess[messageID]
+	delete(q.inProcess, messageID)
+	q.inProcessMu.Unlock()
+	if !ok {
+		return errors.New("sqs message is not in process: " + messageID)
+	}
+
+	_, err := q.svc.ChangeMessageVisibility(ctx, &sqs.ChangeMessageVisibilityInput{
+		QueueUrl:          &q.queueURL,
+		ReceiptHandle:     &ipm.receiptHandle,
+		VisibilityTimeout: 0,
+	})
+	return err
+}
+
+// heartbeatLoop extends the visibility of in process messages every heartbeat interval, until the
+// queue is closed.
+func (q *Queue[T]) heartbeatLoop() {
+	ticker := time.NewTicker(q.cfg.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.stop:
+			return
+		case <-ticker.C:
+			q.heartbeat()
+		}
+	}
+}
+
+// heartbeat extends the visibility of all in process messages. Messages in process for longer
+// than the max age are no longer extended and are forgotten, so that SQS redelivers them once
+// their visibility timeout expires.
+//
+// NOTE: an aged out message may still be in process, so it may be processed twice: its redelivery
+// is returned by ReceiveMany as a new message, and deleting the original fails.
+func (q *Queue[T]) heartbeat() {
+	now := time.Now()
+	var handles []string
+
+	q.inProcessMu.Lock()
+	logger := q.logger
+	for msgID, ipm := range q.inProcess {
+		if age := now.Sub(ipm.receivedAt); age > q.cfg.MaxInProcessAge {
+			delete(q.inProcess, msgID)
+			logger.Warn(
+				"sqs message in process for too long, no longer extending its visibility",
+				"msgID", msgID, "age", age,
+			)
+			continue
+		}
+		handles = append(handles, ipm.receiptHandle)
+	}
+	q.inProcessMu.Unlock()
+
+	for _, receiptHandle := range handles {
+		// A message that can no longer be extended (e.g. it was deleted concurrently) will be
+		// redelivered by SQS, which is handled on receive.
+		//nolint:errcheck // best effort.
+		q.svc.ChangeMessageVisibility(context.TODO(), &sqs.ChangeMessageVisibilityInput{
+			QueueUrl:          &q.queueURL,
+			ReceiptHandle:     &receiptHandle,
+			VisibilityTimeout: int32(q.cfg.VisibilityTimeout.Seconds()),
+		})
+	}
+}
+
+// batchErrors returns the errors of the failed entries of a batch request, if any.
+func batchErrors(failed []sqstypes.BatchResultErrorEntry) error {
+	var errs error
+	for _, entry := range failed {
+		errs = errors.Join(errs, fmt.Errorf(
+			"sqs batch entry %s failed (%s): %s",
+			aws.ToString(entry.Id), aws.ToString(entry.Code), aws.ToString(entry.Message),
+		))
+	}
+	return errs
+}
+
+// isRedelivery returns true if SQS has delivered the message more than once.
+func isRedelivery(m sqstypes.Message) bool {
+	count, err := strconv.Atoi(
+		m.Attributes[string(sqstypes.MessageSystemAttributeNameApproximateReceiveCount)],
+	)
+	return err == nil && count > 1
+}
+
+// newItem returns a new, empty instance of the item type T, which must be a pointer type.
+func newItem[T types.Marshallable]() T {
+	var t T
+	item, _ := reflect.New(reflect.TypeOf(t).Elem()).Interface().(T)
+	return item
+}
@@ -0,0 +1,332 @@
+package sqs_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	queuesqs "github.com/berachain/offchain-sdk/types/queue/sqs"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	Value int
+}
+
+func (i *item) String() string              { return "item" }
+func (*item) New() types.Marshallable       { return &item{} }
+func (i *item) Marshal() ([]byte, error)    { return json.Marshal(i) }
+func (i *item) Unmarshal(data []byte) error { return json.Unmarshal(data, i) }
+
+// fakeSQS is a minimal in-memory SQS. Received messages are hidden until deleted, unless
+// redeliver is set, as if their visibility timeout always expired.
+type fakeSQS struct {
+	mu         sync.Mutex
+	redeliver  bool
+	delay      time.Duration
+	bodies     map[string]string
+	order      []string
+	receives   map[string]int
+	extensions int
+	deleted    []string
+	groupIDs   []string
+}
+
+func newFakeSQS() *fakeSQS {
+	return &fakeSQS{bodies: make(map[string]string), receives: make(map[string]int)}
+}
+
+func (f *fakeSQS) SendMessage(
+	_ context.Context, params *sqs.SendMessageInput, _ ...func(*sqs.Options),
+) (*sqs.SendMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := strconv.Itoa(len(f.order))
+	f.bodies[id] = *params.MessageBody
+	f.order = append(f.order, id)
+	return &sqs.SendMessageOutput{MessageId: &id}, nil
+}
+
+func (f *fakeSQS) SendMessageBatch(
+	ctx context.Context, params *sqs.SendMessageBatchInput, _ ...func(*sqs.Options),
+) (*sqs.SendMessageBatchOutput, error) {
+	output := &sqs.SendMessageBatchOutput{}
+	for _, entry := range params.Entries {
+		f.mu.Lock()
+		f.groupIDs = append(f.groupIDs, aws.ToString(entry.MessageGroupId))
+		f.mu.Unlock()
+		sent, _ := f.SendMessage(ctx, &sqs.SendMessageInput{MessageBody: entry.MessageBody})
+		output.Successful = append(output.Successful, sqstypes.SendMessageBatchResultEntry{
+			Id: entry.Id, MessageId: sent.MessageId,
+		})
+	}
+	return output, nil
+}
+
+func (f *fakeSQS) ReceiveMessage(
+	_ context.Context, params *sqs.ReceiveMessageInput, _ ...func(*sqs.Options),
+) (*sqs.ReceiveMessageOutput, error) {
+	time.Sleep(f.delay)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var msgs []sqstypes.Message
+	for _, id := range f.order {
+		if len(msgs) == int(params.MaxNumberOfMessages) {
+			break
+		}
+		body, ok := f.bodies[id]
+		if !ok || (f.receives[id] > 0 && !f.redeliver) {
+			continue
+		}
+		f.receives[id]++
+		msgID, receiptHandle := id, id+"-"+strconv.Itoa(f.receives[id])
+		msgs = append(msgs, sqstypes.Message{
+			MessageId:     &msgID,
+			ReceiptHandle: &receiptHandle,
+			Body:          &body,
+			Attributes: map[string]string{
+				"ApproximateReceiveCount": strconv.Itoa(f.receives[id]),
+			},
+		})
+	}
+	return &sqs.ReceiveMessageOutput{Messages: msgs}, nil
+}
+
+func (f *fakeSQS) DeleteMessage(
+	_ context.Context, params *sqs.DeleteMessageInput, _ ...func(*sqs.Options),
+) (*sqs.DeleteMessageOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.deleted = append(f.deleted, *params.ReceiptHandle)
+	id, _, _ := strings.Cut(*params.ReceiptHandle, "-")
+	delete(f.bodies, id)
+	return &sqs.DeleteMessageOutput{}, nil
+}
+
+func (f *fakeSQS) DeleteMessageBatch(
+	ctx context.Context, params *sqs.DeleteMessageBatchInput, _ ...func(*sqs.Options),
+) (*sqs.DeleteMessageBatchOutput, error) {
+	output := &sqs.DeleteMessageBatchOutput{}
+	for _, entry := range params.Entries {
+		_, _ = f.DeleteMessage(ctx, &sqs.DeleteMessageInput{ReceiptHandle: entry.ReceiptHandle})
+		output.Successful = append(output.Successful, sqstypes.DeleteMessageBatchResultEntry{
+			Id: entry.Id,
+		})
+	}
+	return output, nil
+}
+
+func (f *fakeSQS) ChangeMessageVisibility(
+	context.Context, *sqs.ChangeMessageVisibilityInput, ...func(*sqs.Options),
+) (*sqs.ChangeMessageVisibilityOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.extensions++
+	return &sqs.ChangeMessageVisibilityOutput{}, nil
+}
+
+func (f *fakeSQS) GetQueueAttributes(
+	context.Context, *sqs.GetQueueAttributesInput, ...func(*sqs.Options),
+) (*sqs.GetQueueAttributesOutput, error) {
+	return &sqs.GetQueueAttributesOutput{}, nil
+}
+
+func TestRedeliveryWhileInProcess(t *testing.T) {
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue"})
+	require.NoError(t, err)
+	defer q.Close()
+
+	id, err := q.Push(&item{Value: 1})
+	require.NoError(t, err)
+
+	_, items, err := q.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+
+	// The redelivered message is not returned again while in process...
+	svc.mu.Lock()
+	svc.redeliver = true
+	svc.mu.Unlock()
+	_, items, err = q.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	// ...and is deleted with the latest receipt handle.
+	require.NoError(t, q.Delete(id))
+	require.Equal(t, []string{id + "-2"}, svc.deleted)
+	require.Zero(t, q.InProcess())
+}
+
+func TestBoundedInProcess(t *testing.T) {
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue", MaxInProcess: 2})
+	require.NoError(t, err)
+	defer q.Close()
+
+	for i := 0; i < 3; i++ {
+		_, err = q.Push(&item{Value: i})
+		require.NoError(t, err)
+	}
+
+	ids, items, err := q.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+
+	// No more messages are received while at capacity.
+	_, items, err = q.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Empty(t, items)
+
+	require.NoError(t, q.Delete(ids[0]))
+	_, items, err = q.ReceiveMany(10)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, 2, items[0].Value)
+}
+
+func TestBoundedInProcessConcurrentReceives(t *testing.T) {
+	svc := newFakeSQS()
+	svc.delay = 10 * time.Millisecond
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue", MaxInProcess: 2})
+	require.NoError(t, err)
+	defer q.Close()
+
+	for i := 0; i < 10; i++ {
+		_, err = q.Push(&item{Value: i})
+		require.NoError(t, err)
+	}
+
+	// Concurrent receives do not overshoot the bound.
+	var received int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, items, rErr := q.ReceiveMany(10)
+			require.NoError(t, rErr)
+			mu.Lock()
+			received += len(items)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, 2, received)
+	require.Equal(t, 2, q.InProcess())
+}
+
+func TestReceiveManySkipsPoisonMessages(t *testing.T) {
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue"})
+	require.NoError(t, err)
+	defer q.Close()
+
+	_, err = q.Push(&item{Value: 0})
+	require.NoError(t, err)
+	_, err = svc.SendMessage(context.Background(), &sqs.SendMessageInput{
+		MessageBody: aws.String("{"),
+	})
+	require.NoError(t, err)
+	_, err = q.Push(&item{Value: 2})
+	require.NoError(t, err)
+
+	// The decoded messages are returned with the error of the poison message, which is not put in
+	// process (so it is not heartbeated, and is redelivered by SQS).
+	ids, items, err := q.ReceiveMany(10)
+	require.Error(t, err)
+	require.Equal(t, []string{"0", "2"}, ids)
+	require.Equal(t, []*item{{Value: 0}, {Value: 2}}, items)
+	require.Equal(t, 2, q.InProcess())
+}
+
+func TestHeartbeatExtendsVisibility(t *testing.T) {
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{
+		QueueURL:          "queue",
+		HeartbeatInterval: 5 * time.Millisecond,
+		MaxInProcessAge:   50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+	defer q.Close()
+
+	_, err = q.Push(&item{Value: 1})
+	require.NoError(t, err)
+	_, _, ok := q.Receive()
+	require.True(t, ok)
+
+	require.Eventually(t, func() bool {
+		svc.mu.Lock()
+		defer svc.mu.Unlock()
+		return svc.extensions > 0
+	}, time.Second, 5*time.Millisecond)
+
+	// Messages in process for longer than the max age are forgotten.
+	require.Eventually(t, func() bool { return q.InProcess() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestFIFOBatches(t *testing.T) {
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue.fifo"})
+	require.NoError(t, err)
+	defer q.Close()
+
+	// Pushes are split into batches of at most 10 messages.
+	items := make([]*item, 15)
+	for i := range items {
+		items[i] = &item{Value: i}
+	}
+	pushedIDs, err := q.PushMany(items)
+	require.NoError(t, err)
+	require.Len(t, pushedIDs, 15)
+	require.NotContains(t, pushedIDs, "")
+	require.Len(t, svc.groupIDs, 15)
+	require.NotContains(t, svc.groupIDs, "")
+
+	received := make([]string, 0, len(items))
+	for len(received) < len(items) {
+		ids, batch, err := q.ReceiveMany(10)
+		require.NoError(t, err)
+		require.NotEmpty(t, batch)
+		received = append(received, ids...)
+	}
+	require.Equal(t, pushedIDs, received)
+
+	// Deletes are also batched, and only succeed for in process messages.
+	require.NoError(t, q.DeleteMany(received...))
+	require.Zero(t, q.InProcess())
+	require.Len(t, svc.deleted, 15)
+	require.Error(t, q.DeleteMany(received[0]))
+}
+
+func TestReleaseV2(t *testing.T) {
+	ctx := context.Background()
+	svc := newFakeSQS()
+	q, err := queuesqs.NewQueue[*item](svc, queuesqs.Config{QueueURL: "queue"})
+	require.NoError(t, err)
+	v2 := queuesqs.AdaptQueue(q)
+	defer v2.Close()
+
+	_, _, err = v2.Receive(ctx)
+	require.ErrorIs(t, err, types.ErrEmpty)
+
+	_, err = v2.Push(ctx, &item{Value: 1})
+	require.NoError(t, err)
+	msgID, _, err := v2.Receive(ctx)
+	require.NoError(t, err)
+
+	// A released message is no longer in process, so its visibility is not extended.
+	require.NoError(t, v2.Release(ctx, msgID))
+	require.Zero(t, q.InProcess())
+	require.Equal(t, 1, svc.extensions)
+	require.Error(t, v2.Release(ctx, msgID))
+}
@@ -0,0 +1,87 @@
+package sqs
+
+import (
+	"context"
+
+	"github.com/berachain/offchain-sdk/log"
+	"github.com/berachain/offchain-sdk/types/queue/types"
+)
+
+// QueueV2 adapts the SQS Queue to the context-aware types.QueueV2 interface, passing the contexts
+// through to the SQS API calls.
+type QueueV2[T types.Marshallable] struct {
+	q *Queue[T]
+}
+
+// NewQueueV2FromConfig creates a new SQS queue implementing types.QueueV2 with the specified
+// config. Close must be called to stop.
+func NewQueueV2FromConfig[T types.Marshallable](cfg Config) (*QueueV2[T], error) {
+	q, err := NewQueueFromConfig[T](cfg)
+	if err != nil {
+		return nil, err
+	}
+	return AdaptQueue(q), nil
+}
+
+// AdaptQueue adapts the given SQS queue to the types.QueueV2 interface.
+func AdaptQueue[T types.Marshallable](q *Queue[T]) *QueueV2[T] {
+	return &QueueV2[T]{q: q}
+}
+
+// Push adds an item to the SQS queue.
+func (a *QueueV2[T]) Push(ctx context.Context, item T) (string, error) {
+	return a.q.push(ctx, item)
+}
+
+// PushMany adds the items to the SQS queue in batches (see Queue.PushMany).
+func (a *QueueV2[T]) PushMany(ctx context.Context, items []T) ([]string, error) {
+	return a.q.pushMany(ctx, items)
+}
+
+// Receive retrieves an item from the SQS queue, or types.ErrEmpty if none is available.
+func (a *QueueV2[T]) Receive(ctx context.Context) (string, T, error) {
+	msgIDs, items, err := a.q.receiveMany(ctx, 1)
+	if err != nil {
+		return "", newItem[T](), err
+	}
+	if len(items) == 0 {
+		return "", newItem[T](), types.ErrEmpty
+	}
+	return msgIDs[0], items[0], nil
+}
+
+// ReceiveMany retrieves at most num items from the SQS queue (see Queue.ReceiveMany).
+func (a *QueueV2[T]) ReceiveMany(ctx context.Context, num int32) ([]string, []T, error) {
+	return a.q.receiveMany(ctx, num)
+}
+
+// Delete deletes the message with the given ID, marking it as complete.
+func (a *QueueV2[T]) Delete(ctx context.Context, msgID string) error {
+	return a.q.deleteMessage(ctx, msgID)
+}
+
+// DeleteMany deletes the messages with the given IDs in batches, marking them as complete.
+func (a *QueueV2[T]) DeleteMany(ctx context.Context, msgIDs ...string) error {
+	return a.q.deleteMany(ctx, msgIDs...)
+}
+
+// Release makes the message with the given ID visible again immediately, so that it is
+// redelivered without waiting for its visibility timeout.
+func (a *QueueV2[T]) Release(ctx context.Context, msgID string) error {
+	return a.q.release(ctx, msgID)
+}
+
+// Len returns the approximate number of messages available in the SQS queue.
+func (a *QueueV2[T]) Len(ctx context.Context) (int, error) {
+	return a.q.length(ctx)
+}
+
+// SetLogger sets the logger of the SQS queue (see Queue.SetLogger).
+func (a *QueueV2[T]) SetLogger(logger log.Logger) {
+	a.q.SetLogger(logger)
+}
+
+// Close stops extending the visibility of in process messages (see Queue.Close).
+func (a *QueueV2[T]) Close() error {
+	return a.q.Close()
+}
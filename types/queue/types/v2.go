@@ -0,0 +1,107 @@
+package types
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrEmpty is returned when receiving from a queue that has no messages available.
+	ErrEmpty = errors.New("queue is empty")
+	// ErrReleaseNotSupported is returned when releasing a message from a queue that cannot return
+	// messages early; the message is redelivered once its visibility timeout expires.
+	ErrReleaseNotSupported = errors.New("queue does not support releasing messages")
+)
+
+// QueueV2 defines the context-aware interaction with a queue. Unlike Queue, every operation
+// returns an explicit error, so that failures are distinguishable from an empty queue.
+type QueueV2[T Marshallable] interface {
+	// Push adds an item to the queue, returning its queue message ID.
+	Push(ctx context.Context, item T) (string, error)
+	// Receive returns the next available item, or ErrEmpty if there is none.
+	Receive(ctx context.Context) (string, T, error)
+	// ReceiveMany returns at most num available items, which may be none.
+	ReceiveMany(ctx context.Context, num int32) ([]string, []T, error)
+	// Delete marks the received message with the given ID as processed.
+	Delete(ctx context.Context, msgID string) error
+	// Release (nack) returns the received message with the given ID to the queue early, so that it
+	// can be redelivered without waiting for its visibility timeout.
+	Release(ctx context.Context, msgID string) error
+	// Len returns the number of items waiting to be received.
+	Len(ctx context.Context) (int, error)
+}
+
+// releaser is implemented by Queues that can return received messages early.
+type releaser interface {
+	Release(msgID string) error
+}
+
+// queueAdapter adapts a Queue to the QueueV2 interface.
+type queueAdapter[T Marshallable] struct {
+	q Queue[T]
+}
+
+// AdaptQueue adapts the given Queue, which does not take contexts, to the QueueV2 interface. The
+// contexts are only checked for cancellation before each operation. Messages can only be released
+// if the Queue implements `Release(msgID string) error`.
+func AdaptQueue[T Marshallable](q Queue[T]) QueueV2[T] {
+	return &queueAdapter[T]{q: q}
+}
+
+func (a *queueAdapter[T]) Push(ctx context.Context, item T) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	return a.q.Push(item)
+}
+
+func (a *queueAdapter[T]) Receive(ctx context.Context) (string, T, error) {
+	var zero T
+	msgIDs, items, err := a.ReceiveMany(ctx, 1)
+	if err != nil {
+		return "", zero, err
+	}
+	if len(items) == 0 {
+		return "", zero, ErrEmpty
+	}
+	return msgIDs[0], items[0], nil
+}
+
+func (a *queueAdapter[T]) ReceiveMany(ctx context.Context, num int32) ([]string, []T, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	return a.q.ReceiveMany(num)
+}
+
+func (a *queueAdapter[T]) Delete(ctx context.Context, msgID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return a.q.Delete(msgID)
+}
+
+func (a *queueAdapter[T]) Release(ctx context.Context, msgID string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if r, ok := a.q.(releaser); ok {
+		return r.Release(msgID)
+	}
+	return ErrReleaseNotSupported
+}
+
+func (a *queueAdapter[T]) Len(ctx context.Context) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	return a.q.Len(), nil
+}
+
+// Close closes the adapted Queue, if it can be closed.
+func (a *queueAdapter[T]) Close() error {
+	if c, ok := a.q.(interface{ Close() error }); ok {
+		return c.Close()
+	}
+	return nil
+}
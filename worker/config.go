@@ -1,6 +1,11 @@
 package worker
 
-import "github.com/alitto/pond"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alitto/pond"
+)
 
 // PoolConfig is the configuration for a pool.
 type PoolConfig struct {
@@ -29,21 +34,109 @@ func DefaultPoolConfig() *PoolConfig {
 		PrometheusPrefix: "default",
 		MinWorkers:       4,  //nolint:gomnd // it's ok.
 		MaxWorkers:       32, //nolint:gomnd // it's ok.
-		ResizingStrategy: "balanced",
+		ResizingStrategy: ResizingStrategyBalanced,
 		MaxQueuedJobs:    100, //nolint:gomnd // it's ok.
 	}
 }
 
+// Resizing strategies of a pool.
+const (
+	ResizingStrategyEager    = "eager"
+	ResizingStrategyLazy     = "lazy"
+	ResizingStrategyBalanced = "balanced"
+)
+
+// Config is the configuration of the worker pools of the job manager.
+type Config struct {
+	// Producer configures the pool that runs the job producers. Its workers and queue are always
+	// at least the number of jobs, as each producer occupies a worker.
+	Producer PoolConfig
+	// Executor configures the shared pool that executes jobs.
+	Executor PoolConfig
+	// Pools configure the dedicated pools, by name, that jobs can be executed in.
+	Pools map[string]PoolConfig
+}
+
+// Validate returns an error if any of the pool configs are invalid.
+func (c *Config) Validate() error {
+	if err := c.Producer.Validate(); err != nil {
+		return fmt.Errorf("workers.producer: %w", err)
+	}
+	if err := c.Executor.Validate(); err != nil {
+		return fmt.Errorf("workers.executor: %w", err)
+	}
+	for name, pool := range c.Pools {
+		if err := pool.Validate(); err != nil {
+			return fmt.Errorf("workers.pools.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Pool returns the config of the dedicated pool with the given name, if any. Names are matched
+// case-insensitively, as config keys are lower-cased.
+func (c *Config) Pool(name string) (PoolConfig, bool) {
+	for poolName, pool := range c.Pools {
+		if strings.EqualFold(poolName, name) {
+			return pool, true
+		}
+	}
+	return PoolConfig{}, false
+}
+
+// Validate returns an error if the pool config is invalid. Unset (zero) fields are valid, as they
+// are replaced by defaults (see WithDefaults).
+func (c *PoolConfig) Validate() error {
+	if c.ResizingStrategy != "" {
+		if _, err := resizerFromString(c.ResizingStrategy); err != nil {
+			return err
+		}
+	}
+	if c.MaxWorkers != 0 && c.MinWorkers > c.MaxWorkers {
+		return fmt.Errorf(
+			"min workers (%d) must not exceed max workers (%d)", c.MinWorkers, c.MaxWorkers,
+		)
+	}
+	return nil
+}
+
+// WithDefaults returns a copy of the pool config, with any unset (zero) fields set to the given
+// defaults.
+func (c PoolConfig) WithDefaults(defaults *PoolConfig) *PoolConfig {
+	if c.Name == "" {
+		c.Name = defaults.Name
+	}
+	if c.PrometheusPrefix == "" {
+		c.PrometheusPrefix = defaults.PrometheusPrefix
+	}
+	if c.MaxWorkers == 0 {
+		c.MaxWorkers = max(defaults.MaxWorkers, c.MinWorkers)
+	}
+	if c.MinWorkers == 0 {
+		c.MinWorkers = min(defaults.MinWorkers, c.MaxWorkers)
+	}
+	if c.ResizingStrategy == "" {
+		c.ResizingStrategy = defaults.ResizingStrategy
+	}
+	if c.MaxQueuedJobs == 0 {
+		c.MaxQueuedJobs = defaults.MaxQueuedJobs
+	}
+	return &c
+}
+
 // resizerFromString returns a pond resizer for the given name.
-func resizerFromString(name string) pond.ResizingStrategy {
+func resizerFromString(name string) (pond.ResizingStrategy, error) {
 	switch name {
-	case "eager":
-		return pond.Eager()
-	case "lazy":
-		return pond.Lazy()
-	case "balanced":
-		return pond.Balanced()
+	case ResizingStrategyEager:
+		return pond.Eager(), nil
+	case ResizingStrategyLazy:
+		return pond.Lazy(), nil
+	case ResizingStrategyBalanced:
+		return pond.Balanced(), nil
 	default:
-		panic("invalid resizer name")
+		return nil, fmt.Errorf(
+			"invalid resizing strategy %q, must be one of %q, %q or %q", name,
+			ResizingStrategyEager, ResizingStrategyLazy, ResizingStrategyBalanced,
+		)
 	}
 }
@@ -0,0 +1,35 @@
+package worker_test
+
+import (
+	"testing"
+
+	"github.com/berachain/offchain-sdk/worker"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	cfg := worker.Config{
+		Executor: worker.PoolConfig{MaxWorkers: 8},
+		Pools: map[string]worker.PoolConfig{
+			"events": {ResizingStrategy: worker.ResizingStrategyLazy},
+		},
+	}
+	require.NoError(t, cfg.Validate())
+
+	cfg.Pools["events"] = worker.PoolConfig{ResizingStrategy: "greedy"}
+	require.ErrorContains(
+		t, cfg.Validate(), `workers.pools.events: invalid resizing strategy "greedy"`,
+	)
+
+	cfg.Pools = nil
+	cfg.Executor.MinWorkers = 16
+	require.ErrorContains(t, cfg.Validate(), "workers.executor: min workers (16)")
+}
+
+func TestPoolConfigWithDefaults(t *testing.T) {
+	cfg := worker.PoolConfig{MinWorkers: 64}.WithDefaults(worker.DefaultPoolConfig())
+	require.Equal(t, uint16(64), cfg.MinWorkers)
+	require.Equal(t, uint16(64), cfg.MaxWorkers)
+	require.Equal(t, worker.ResizingStrategyBalanced, cfg.ResizingStrategy)
+	require.Equal(t, uint16(100), cfg.MaxQueuedJobs)
+}
@@ -2,6 +2,7 @@ package worker
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/alitto/pond"
 	"github.com/berachain/offchain-sdk/log"
@@ -18,14 +19,18 @@ type TaskGroup struct {
 	*pond.TaskGroupWithContext
 }
 
-// NewPool creates a new pool.
+// NewPool creates a new pool. The config must be valid (see PoolConfig.Validate).
 func NewPool(ctx context.Context, logger log.Logger, cfg *PoolConfig) *Pool {
+	resizer, err := resizerFromString(cfg.ResizingStrategy)
+	if err != nil {
+		panic(fmt.Errorf("pool %s: %w", cfg.Name, err))
+	}
 	p := &Pool{
 		name: cfg.Name,
 		WorkerPool: pond.New(
 			int(cfg.MaxWorkers),
 			int(cfg.MaxQueuedJobs),
-			pond.Strategy(resizerFromString(cfg.ResizingStrategy)),
+			pond.Strategy(resizer),
 			pond.Context(ctx), // allows for cancelling jobs.
 			pond.MinWorkers(int(cfg.MinWorkers)),
 			pond.PanicHandler(PanicHandler(logger)),
@@ -0,0 +1,303 @@
+package jobs
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const (
+	defaultBackfillChunkSize = 1000
+
+	// checkpointLen is the length of an encoded checkpoint: the block and log index, as uint64s.
+	checkpointLen = 16
+)
+
+var (
+	// ErrBackfillNoDB is returned when subscribing a job with backfill enabled, if the app has no
+	// DB to checkpoint the processed logs in.
+	ErrBackfillNoDB = errors.New("backfill requires an app DB")
+
+	// checkpointPrefix is the key prefix of the checkpoints of the jobs' processed logs.
+	checkpointPrefix = []byte("jobs/backfill/")
+)
+
+// BackfillConfig configures the backfill of the logs that an event job missed while it was not
+// subscribed (e.g. while the app was down, or while resubscribing).
+//
+// Logs are checkpointed once executed, so checkpoints are only as final as the executed logs: a
+// log reverted after being executed (see job.ReorgConfig) stays checkpointed, and the logs that
+// replace it at earlier positions are not replayed on restart. Jobs that must not miss them should
+// set a FinalityDepth deeper than the reorgs they expect, so that only final logs are executed.
+type BackfillConfig struct {
+	// StartBlock is the block to backfill from on the first run, when no log has been checkpointed
+	// yet. If 0, the first run follows logs from the head of the chain.
+	StartBlock uint64
+	// ChunkSize is the max number of blocks queried per FilterLogs call. Defaults to 1000.
+	ChunkSize uint64
+}
+
+// withDefaults returns the config with any unset fields set to their defaults.
+func (c BackfillConfig) withDefaults() BackfillConfig {
+	if c.ChunkSize == 0 {
+		c.ChunkSize = defaultBackfillChunkSize
+	}
+	return c
+}
+
+// logPosition is the position of a log in the chain.
+type logPosition struct {
+	block uint64
+	index uint64
+}
+
+// positionOf returns the position of the given log.
+func positionOf(l coretypes.Log) logPosition {
+	return logPosition{block: l.BlockNumber, index: uint64(l.Index)}
+}
+
+// next returns the position right after p.
+func (p logPosition) next() logPosition {
+	return logPosition{block: p.block, index: p.index + 1}
+}
+
+// less returns whether p is before o.
+func (p logPosition) less(o logPosition) bool {
+	return p.block < o.block || (p.block == o.block && p.index < o.index)
+}
+
+// backfill replays the logs missed by an event job with FilterLogs before following its live
+// subscription, and checkpoints the logs processed by the job in the app DB. Logs are delivered in
+// order, each at most once per run; with concurrent executions, a log whose execution fails may be
+// passed by the checkpoint of a later log, so jobs that must not skip logs should execute serially.
+type backfill struct {
+	// cfg is nil if backfill is disabled.
+	cfg *BackfillConfig
+
+	mu sync.Mutex
+	// next is the position of the next log to deliver; unset until first subscribed.
+	next *logPosition
+	// checkpoint is the position of the next log to process, as persisted.
+	checkpoint logPosition
+}
+
+// subscribe subscribes to the logs matching the given query. If backfill is enabled, the logs since
+// the last delivered (or, on startup, processed) log are replayed first, and the live logs up to
+// the block they were replayed to are skipped, so that none are duplicated or skipped at the seam.
+func (b *backfill) subscribe(
+	ctx context.Context, key string, query ethereum.FilterQuery,
+) (ethereum.Subscription, chan coretypes.Log, error) {
+	sCtx := sdk.UnwrapContext(ctx)
+	ch := make(chan coretypes.Log)
+	if b.cfg == nil {
+		sub, err := sCtx.Chain().SubscribeFilterLogs(ctx, query, ch)
+		return sub, ch, err
+	}
+	if sCtx.DB() == nil {
+		return nil, nil, ErrBackfillNoDB
+	}
+
+	// Subscribe before reading the head, so that every log after the head is delivered live.
+	live := make(chan coretypes.Log)
+	liveSub, err := sCtx.Chain().SubscribeFilterLogs(ctx, query, live)
+	if err != nil {
+		return nil, nil, err
+	}
+	head, err := sCtx.Chain().BlockNumber(ctx)
+	if err != nil {
+		liveSub.Unsubscribe()
+		return nil, nil, err
+	}
+	from, err := b.start(sCtx.DB(), key, head)
+	if err != nil {
+		liveSub.Unsubscribe()
+		return nil, nil, err
+	}
+	if from.block <= head {
+		sCtx.Logger().Info("backfilling logs", "job", key, "from", from.block, "to", head)
+	}
+
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		defer liveSub.Unsubscribe()
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-subCtx.Done():
+			}
+		}()
+
+		if replayErr := b.replay(
+			subCtx, sCtx.Chain(), query, from.block, head, ch,
+		); replayErr != nil {
+			if subCtx.Err() != nil {
+				return nil
+			}
+			return replayErr
+		}
+		for {
+			select {
+			case <-quit:
+				return nil
+			case liveErr := <-liveSub.Err():
+				return liveErr
+			case l := <-live:
+				if l.BlockNumber <= head && !l.Removed {
+					continue
+				}
+				if !b.deliver(subCtx, l, ch) {
+					return nil
+				}
+			}
+		}
+	})
+	return sub, ch, nil
+}
+
+// start returns the position to backfill from, loading it from the checkpoint on startup.
+func (b *backfill) start(db ethdb.KeyValueStore, key string, head uint64) (logPosition, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.next != nil {
+		return *b.next, nil
+	}
+
+	pos, found, err := loadCheckpoint(db, key)
+	if err != nil {
+		return logPosition{}, err
+	}
+	if !found {
+		pos = logPosition{block: head + 1}
+		if b.cfg.StartBlock > 0 {
+			pos = logPosition{block: b.cfg.StartBlock}
+		}
+		// Persist the start, so that a restart before any log is processed resumes from it.
+		if err = db.Put(checkpointKey(key), encodePosition(pos)); err != nil {
+			return logPosition{}, err
+		}
+	}
+	b.next, b.checkpoint = &pos, pos
+	return pos, nil
+}
+
+// replay delivers the logs matching the query from the given block up to head, querying them in
+// chunks, then advances the next position past head.
+func (b *backfill) replay(
+	ctx context.Context, chain eth.Client, query ethereum.FilterQuery,
+	from, head uint64, ch chan<- coretypes.Log,
+) error {
+	for start := from; start <= head; start += b.cfg.ChunkSize {
+		q := query
+		q.FromBlock = new(big.Int).SetUint64(start)
+		q.ToBlock = new(big.Int).SetUint64(min(start+b.cfg.ChunkSize-1, head))
+		logs, err := chain.FilterLogs(ctx, q)
+		if err != nil {
+			return err
+		}
+		for _, l := range logs {
+			if !b.deliver(ctx, l, ch) {
+				return ctx.Err()
+			}
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if after := (logPosition{block: head + 1}); b.next.less(after) {
+		b.next = &after
+	}
+	return nil
+}
+
+// deliver sends the given log on the channel, unless it was already delivered, and advances the
+// next position past it. Removed logs are always sent. It returns false if the context is done.
+func (b *backfill) deliver(ctx context.Context, l coretypes.Log, ch chan<- coretypes.Log) bool {
+	pos := positionOf(l)
+	b.mu.Lock()
+	skip := !l.Removed && pos.less(*b.next)
+	b.mu.Unlock()
+	if skip {
+		return true
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case ch <- l:
+	}
+
+	if !l.Removed {
+		b.mu.Lock()
+		if next := pos.next(); b.next.less(next) {
+			b.next = &next
+		}
+		b.mu.Unlock()
+	}
+	return true
+}
+
+// processed checkpoints the given log (if it is one) as processed by the job with the given key.
+func (b *backfill) processed(ctx context.Context, key string, args any) {
+	l, ok := args.(coretypes.Log)
+	if b.cfg == nil || !ok || l.Removed {
+		return
+	}
+	sCtx := sdk.UnwrapContext(ctx)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	next := positionOf(l).next()
+	if !b.checkpoint.less(next) {
+		return
+	}
+	if err := sCtx.DB().Put(checkpointKey(key), encodePosition(next)); err != nil {
+		sCtx.Logger().Error("error checkpointing log", "job", key, "block", l.BlockNumber,
+			"err", err)
+		return
+	}
+	b.checkpoint = next
+}
+
+// loadCheckpoint loads the checkpoint of the job with the given key, if any.
+func loadCheckpoint(db ethdb.KeyValueStore, key string) (logPosition, bool, error) {
+	has, err := db.Has(checkpointKey(key))
+	if err != nil || !has {
+		return logPosition{}, false, err
+	}
+	bz, err := db.Get(checkpointKey(key))
+	if err != nil {
+		return logPosition{}, false, err
+	}
+	if len(bz) != checkpointLen {
+		return logPosition{}, false, fmt.Errorf(
+			"invalid checkpoint of job %s: %d bytes, expected %d", key, len(bz), checkpointLen,
+		)
+	}
+	return logPosition{
+		block: binary.BigEndian.Uint64(bz[:8]),
+		index: binary.BigEndian.Uint64(bz[8:]),
+	}, true, nil
+}
+
+// checkpointKey returns the DB key of the checkpoint of the job with the given key.
+func checkpointKey(key string) []byte {
+	return append(append([]byte{}, checkpointPrefix...), key...)
+}
+
+// encodePosition encodes the given position as stored in a checkpoint.
+func encodePosition(pos logPosition) []byte {
+	return binary.BigEndian.AppendUint64(binary.BigEndian.AppendUint64(nil, pos.block), pos.index)
+}
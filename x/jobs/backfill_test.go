@@ -0,0 +1,136 @@
+package jobs_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/berachain/offchain-sdk/x/jobs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// logChain serves the logs of a fake chain up to its head, and delivers the live logs it is fed
+// to its log subscription.
+type logChain struct {
+	eth.Client
+	head    uint64
+	logs    []coretypes.Log
+	live    chan coretypes.Log
+	queries int
+	query   ethereum.FilterQuery
+}
+
+func (c *logChain) BlockNumber(context.Context) (uint64, error) { return c.head, nil }
+
+func (c *logChain) FilterLogs(_ context.Context, q ethereum.FilterQuery) ([]coretypes.Log, error) {
+	c.queries++
+	var logs []coretypes.Log
+	for _, l := range c.logs {
+		if l.BlockNumber >= q.FromBlock.Uint64() && l.BlockNumber <= q.ToBlock.Uint64() {
+			logs = append(logs, l)
+		}
+	}
+	return logs, nil
+}
+
+func (c *logChain) SubscribeFilterLogs(
+	_ context.Context, q ethereum.FilterQuery, ch chan<- coretypes.Log,
+) (ethereum.Subscription, error) {
+	c.query = q
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		for {
+			select {
+			case <-quit:
+				return nil
+			case l := <-c.live:
+				select {
+				case ch <- l:
+				case <-quit:
+					return nil
+				}
+			}
+		}
+	}), nil
+}
+
+// noopJob is a basic job that does nothing.
+type noopJob struct{}
+
+func (noopJob) RegistryKey() string { return "noop" }
+
+func (noopJob) Execute(context.Context, any) (any, error) { return nil, nil } //nolint:nilnil // test.
+
+// receiveLogs receives the given number of logs from the channel, returning their block numbers.
+func receiveLogs(t *testing.T, ch chan coretypes.Log, n int) []uint64 {
+	var blocks []uint64
+	for len(blocks) < n {
+		select {
+		case l := <-ch:
+			blocks = append(blocks, l.BlockNumber)
+		case <-time.After(time.Second):
+			t.Fatalf("received %d of %d logs", len(blocks), n)
+		}
+	}
+	return blocks
+}
+
+func TestBackfillResumesFromCheckpoint(t *testing.T) {
+	db := memorydb.New()
+	chain := &logChain{
+		head: 5,
+		logs: []coretypes.Log{
+			{BlockNumber: 1}, {BlockNumber: 2, Index: 0}, {BlockNumber: 2, Index: 1},
+			{BlockNumber: 4}, {BlockNumber: 5},
+		},
+		live: make(chan coretypes.Log),
+	}
+	sCtx := sdk.NewContext(context.Background(), chain, log.NewLogger(os.Stdout, "test-runner"), db)
+
+	j := jobs.NewEthSub(noopJob{}, "0x0", "Event()").
+		WithBackfill(jobs.BackfillConfig{StartBlock: 1, ChunkSize: 2})
+	sub, ch, err := j.Subscribe(sCtx)
+	require.NoError(t, err)
+
+	// The missed logs are replayed in chunks, then live logs after the head are delivered.
+	require.Equal(t, []uint64{1, 2, 2, 4, 5}, receiveLogs(t, ch, 5))
+	require.Equal(t, 3, chain.queries)
+	chain.live <- coretypes.Log{BlockNumber: 5}
+	chain.live <- coretypes.Log{BlockNumber: 6}
+	require.Equal(t, []uint64{6}, receiveLogs(t, ch, 1))
+
+	for _, l := range []coretypes.Log{{BlockNumber: 5}, {BlockNumber: 6}} {
+		_, err = j.Execute(sCtx, l)
+		require.NoError(t, err)
+	}
+	sub.Unsubscribe()
+
+	// On restart, only the logs after the last processed one are replayed.
+	chain.head = 7
+	chain.logs = append(chain.logs, coretypes.Log{BlockNumber: 6}, coretypes.Log{BlockNumber: 7})
+	j = jobs.NewEthSub(noopJob{}, "0x0", "Event()").
+		WithBackfill(jobs.BackfillConfig{StartBlock: 1, ChunkSize: 2})
+	sub, ch, err = j.Subscribe(sCtx)
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+	require.Equal(t, []uint64{7}, receiveLogs(t, ch, 1))
+}
+
+func TestBackfillRejectsInvalidCheckpoint(t *testing.T) {
+	db := memorydb.New()
+	chain := &logChain{head: 5, live: make(chan coretypes.Log)}
+	sCtx := sdk.NewContext(context.Background(), chain, log.NewLogger(os.Stdout, "test-runner"), db)
+
+	j := jobs.NewEthSub(noopJob{}, "0x0", "Event()").WithBackfill(jobs.BackfillConfig{})
+	require.NoError(t, db.Put([]byte("jobs/backfill/"+j.RegistryKey()), []byte{1, 2, 3}))
+	_, _, err := j.Subscribe(sCtx)
+	require.ErrorContains(t, err, "invalid checkpoint")
+}
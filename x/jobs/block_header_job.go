@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 	sdk "github.com/berachain/offchain-sdk/types"
 
 	"github.com/ethereum/go-ethereum"
@@ -51,6 +52,12 @@ func (w *BlockHeaderWatcher) Unsubscribe(context.Context) {
 	}
 }
 
+// Unwrap implements jobtypes.Unwrapper, so that the basic job's policy and error handler are
+// honored.
+func (w *BlockHeaderWatcher) Unwrap() jobtypes.Executable {
+	return w.Basic
+}
+
 func (w *BlockHeaderWatcher) Setup(ctx context.Context) error {
 	if setupJob, ok := w.Basic.(job.HasSetup); ok {
 		return setupJob.Setup(ctx)
@@ -0,0 +1,195 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	sdk "github.com/berachain/offchain-sdk/types"
+
+	"github.com/ethereum/go-ethereum"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const defaultBlockTagPollInterval = 6 * time.Second
+
+// Compile time check to ensure that BlockTagWatcher implements job.BlockHeaderSub, and optionally
+// the basic job's Setup and Teardown methods.
+var (
+	_ job.BlockHeaderSub = (*BlockTagWatcher)(nil)
+	_ job.HasSetup       = (*BlockTagWatcher)(nil)
+	_ job.HasTeardown    = (*BlockTagWatcher)(nil)
+)
+
+// BlockTagWatcher allows you to subscribe a basic job to the headers of the blocks that become
+// finalized (or safe), by polling the header of the block with the `finalized` (or `safe`) tag.
+// Every block up to the tagged block is delivered, in order, including those the tag skipped over
+// since it was last polled, so that jobs can act only on finalized (or safe) data.
+type BlockTagWatcher struct {
+	job.Basic
+	tag      rpc.BlockNumber
+	interval time.Duration
+	sub      ethereum.Subscription
+
+	mu sync.Mutex
+	// last is the number of the last delivered block; unset until the first block is delivered.
+	last *uint64
+}
+
+// NewFinalizedBlockWatcher creates a new BlockTagWatcher of finalized blocks, which polls the
+// finalized block at the given interval (defaults to 6s if 0).
+func NewFinalizedBlockWatcher(basic job.Basic, interval time.Duration) *BlockTagWatcher {
+	return newBlockTagWatcher(basic, rpc.FinalizedBlockNumber, interval)
+}
+
+// NewSafeBlockWatcher creates a new BlockTagWatcher of safe blocks, which polls the safe block at
+// the given interval (defaults to 6s if 0).
+func NewSafeBlockWatcher(basic job.Basic, interval time.Duration) *BlockTagWatcher {
+	return newBlockTagWatcher(basic, rpc.SafeBlockNumber, interval)
+}
+
+// newBlockTagWatcher creates a new BlockTagWatcher of the blocks with the given tag.
+func newBlockTagWatcher(
+	basic job.Basic, tag rpc.BlockNumber, interval time.Duration,
+) *BlockTagWatcher {
+	if interval == 0 {
+		interval = defaultBlockTagPollInterval
+	}
+	return &BlockTagWatcher{
+		Basic:    basic,
+		tag:      tag,
+		interval: interval,
+	}
+}
+
+// Subscribe subscribes to the blocks with the watcher's tag. The first subscription starts from
+// the tagged block; resubscriptions resume after the last delivered block.
+func (w *BlockTagWatcher) Subscribe(
+	ctx context.Context,
+) (ethereum.Subscription, chan *coretypes.Header, error) {
+	sCtx := sdk.UnwrapContext(ctx)
+	tagged, err := w.tagged(ctx, sCtx.Chain())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	headerCh := make(chan *coretypes.Header)
+	sub := event.NewSubscription(func(quit <-chan struct{}) error {
+		subCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-quit:
+				cancel()
+			case <-subCtx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+		for header := tagged; ; {
+			if pollErr := w.deliver(subCtx, sCtx.Chain(), header, headerCh); pollErr != nil {
+				if subCtx.Err() != nil {
+					return nil
+				}
+				return pollErr
+			}
+			select {
+			case <-subCtx.Done():
+				return nil
+			case <-ticker.C:
+			}
+
+			var pollErr error
+			if header, pollErr = w.tagged(subCtx, sCtx.Chain()); pollErr != nil {
+				if subCtx.Err() != nil {
+					return nil
+				}
+				return pollErr
+			}
+		}
+	})
+	w.sub = sub
+
+	sCtx.Logger().Info("Subscribed to block headers", "tag", w.tag.String())
+	return sub, headerCh, nil
+}
+
+// tagged returns the header of the block with the watcher's tag.
+func (w *BlockTagWatcher) tagged(ctx context.Context, chain eth.Client) (*coretypes.Header, error) {
+	header, err := chain.HeaderByNumber(ctx, big.NewInt(w.tag.Int64()))
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("no %s block", w.tag.String())
+	}
+	return header, nil
+}
+
+// deliver sends the headers of the blocks after the last delivered block, up to the given tagged
+// block, in order.
+func (w *BlockTagWatcher) deliver(
+	ctx context.Context, chain eth.Client, tagged *coretypes.Header, ch chan<- *coretypes.Header,
+) error {
+	w.mu.Lock()
+	from := tagged.Number.Uint64()
+	if w.last != nil {
+		from = *w.last + 1
+	}
+	w.mu.Unlock()
+
+	for n := from; n <= tagged.Number.Uint64(); n++ {
+		header := tagged
+		if n < tagged.Number.Uint64() {
+			var err error
+			if header, err = chain.HeaderByNumber(ctx, new(big.Int).SetUint64(n)); err != nil {
+				return err
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ch <- header:
+		}
+
+		last := n
+		w.mu.Lock()
+		w.last = &last
+		w.mu.Unlock()
+	}
+	return nil
+}
+
+func (w *BlockTagWatcher) Unsubscribe(context.Context) {
+	if w.sub != nil {
+		w.sub.Unsubscribe()
+	}
+}
+
+// Unwrap implements jobtypes.Unwrapper, so that the basic job's policy and error handler are
+// honored.
+func (w *BlockTagWatcher) Unwrap() jobtypes.Executable {
+	return w.Basic
+}
+
+func (w *BlockTagWatcher) Setup(ctx context.Context) error {
+	if setupJob, ok := w.Basic.(job.HasSetup); ok {
+		return setupJob.Setup(ctx)
+	}
+	return nil
+}
+
+func (w *BlockTagWatcher) Teardown() error {
+	if setupJob, ok := w.Basic.(job.HasTeardown); ok {
+		return setupJob.Teardown()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package jobs_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/berachain/offchain-sdk/client/eth"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/berachain/offchain-sdk/x/jobs"
+	"github.com/stretchr/testify/require"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// finalizingChain serves the headers of a fake chain, whose finalized block can be advanced.
+type finalizingChain struct {
+	eth.Client
+	mu        sync.Mutex
+	finalized uint64
+}
+
+func (c *finalizingChain) finalize(n uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.finalized = n
+}
+
+func (c *finalizingChain) HeaderByNumber(
+	_ context.Context, n *big.Int,
+) (*coretypes.Header, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if n.Int64() == rpc.FinalizedBlockNumber.Int64() {
+		return &coretypes.Header{Number: new(big.Int).SetUint64(c.finalized)}, nil
+	}
+	return &coretypes.Header{Number: n}, nil
+}
+
+func TestFinalizedBlockWatcherDeliversEveryBlock(t *testing.T) {
+	chain := &finalizingChain{finalized: 2}
+	sCtx := sdk.NewContext(
+		context.Background(), chain, log.NewLogger(os.Stdout, "test-runner"), nil,
+	)
+
+	w := jobs.NewFinalizedBlockWatcher(noopJob{}, time.Millisecond)
+	_, ch, err := w.Subscribe(sCtx)
+	require.NoError(t, err)
+	defer w.Unsubscribe(sCtx)
+
+	receive := func() uint64 {
+		select {
+		case h := <-ch:
+			return h.Number.Uint64()
+		case <-time.After(time.Second):
+			t.Fatal("no header delivered")
+			return 0
+		}
+	}
+
+	// The first subscription starts from the finalized block, then every block the finalized tag
+	// advances over is delivered, in order.
+	require.Equal(t, uint64(2), receive())
+	chain.finalize(5)
+	for n := uint64(3); n <= 5; n++ {
+		require.Equal(t, n, receive())
+	}
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/berachain/offchain-sdk/job"
-	sdk "github.com/berachain/offchain-sdk/types"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 
 	"github.com/ethereum/go-ethereum"
 	coretypes "github.com/ethereum/go-ethereum/core/types"
@@ -23,6 +23,7 @@ type EthFilterSub struct {
 	job.Basic
 	eventFilter ethereum.FilterQuery
 	sub         ethereum.Subscription
+	backfill    backfill
 }
 
 // NewEthFilterSub creates a new EthFilterSub
@@ -34,13 +35,21 @@ func NewEthFilterSub(job job.Basic, eventFilter ethereum.FilterQuery) *EthFilter
 	}
 }
 
-// Subscribe subscribes to all events based on ethereum filter query.
+// WithBackfill enables the backfill of the events missed while the job was not subscribed, which
+// requires the app to have a DB (see BackfillConfig). The block range of the filter query is
+// ignored.
+func (j *EthFilterSub) WithBackfill(cfg BackfillConfig) *EthFilterSub {
+	cfg = cfg.withDefaults()
+	j.backfill.cfg = &cfg
+	return j
+}
+
+// Subscribe subscribes to all events based on ethereum filter query, first replaying the missed
+// events if backfill is enabled.
 func (j *EthFilterSub) Subscribe(
 	ctx context.Context,
 ) (ethereum.Subscription, chan coretypes.Log, error) {
-	sCtx := sdk.UnwrapContext(ctx)
-	ch := make(chan coretypes.Log)
-	sub, err := sCtx.Chain().SubscribeFilterLogs(ctx, j.eventFilter, ch)
+	sub, ch, err := j.backfill.subscribe(ctx, j.RegistryKey(), j.eventFilter)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -55,6 +64,22 @@ func (j *EthFilterSub) Unsubscribe(_ context.Context) {
 	}
 }
 
+// Execute executes the basic job, checkpointing the event as processed if it succeeds and backfill
+// is enabled. Events are checkpointed whether or not they are final (see BackfillConfig).
+func (j *EthFilterSub) Execute(ctx context.Context, args any) (any, error) {
+	res, err := j.Basic.Execute(ctx, args)
+	if err == nil {
+		j.backfill.processed(ctx, j.RegistryKey(), args)
+	}
+	return res, err
+}
+
+// Unwrap implements jobtypes.Unwrapper, so that the basic job's policy and error handler are
+// honored.
+func (j *EthFilterSub) Unwrap() jobtypes.Executable {
+	return j.Basic
+}
+
 func (j *EthFilterSub) Setup(ctx context.Context) error {
 	if setupJob, ok := j.Basic.(job.HasSetup); ok {
 		return setupJob.Setup(ctx)
@@ -4,7 +4,7 @@ import (
 	"context"
 
 	"github.com/berachain/offchain-sdk/job"
-	sdk "github.com/berachain/offchain-sdk/types"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -26,6 +26,7 @@ type EthEventSub struct {
 	contractAddress common.Address
 	event           string
 	sub             ethereum.Subscription
+	backfill        backfill
 }
 
 // NewEthSub creates a new EthEventSub.
@@ -37,16 +38,23 @@ func NewEthSub(job job.Basic, contractAddr string, event string) *EthEventSub {
 	}
 }
 
-// Subscribe subscribes to an ethereum event.
+// WithBackfill enables the backfill of the events missed while the job was not subscribed, which
+// requires the app to have a DB (see BackfillConfig).
+func (j *EthEventSub) WithBackfill(cfg BackfillConfig) *EthEventSub {
+	cfg = cfg.withDefaults()
+	j.backfill.cfg = &cfg
+	return j
+}
+
+// Subscribe subscribes to an ethereum event, first replaying the missed events if backfill is
+// enabled.
 func (j *EthEventSub) Subscribe(
 	ctx context.Context,
 ) (ethereum.Subscription, chan coretypes.Log, error) {
-	sCtx := sdk.UnwrapContext(ctx)
-	ch := make(chan coretypes.Log)
-	sub, err := sCtx.Chain().SubscribeFilterLogs(ctx, ethereum.FilterQuery{
+	sub, ch, err := j.backfill.subscribe(ctx, j.RegistryKey(), ethereum.FilterQuery{
 		Addresses: []common.Address{j.contractAddress},
 		Topics:    [][]common.Hash{{crypto.Keccak256Hash([]byte(j.event))}},
-	}, ch)
+	})
 	if err != nil {
 		return nil, nil, err
 	}
@@ -62,6 +70,22 @@ func (j *EthEventSub) Unsubscribe(_ context.Context) {
 	}
 }
 
+// Execute executes the basic job, checkpointing the event as processed if it succeeds and backfill
+// is enabled. Events are checkpointed whether or not they are final (see BackfillConfig).
+func (j *EthEventSub) Execute(ctx context.Context, args any) (any, error) {
+	res, err := j.Basic.Execute(ctx, args)
+	if err == nil {
+		j.backfill.processed(ctx, j.RegistryKey(), args)
+	}
+	return res, err
+}
+
+// Unwrap implements jobtypes.Unwrapper, so that the basic job's policy and error handler are
+// honored.
+func (j *EthEventSub) Unwrap() jobtypes.Executable {
+	return j.Basic
+}
+
 func (j *EthEventSub) Setup(ctx context.Context) error {
 	if setupJob, ok := j.Basic.(job.HasSetup); ok {
 		return setupJob.Setup(ctx)
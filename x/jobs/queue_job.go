@@ -0,0 +1,172 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	sdk "github.com/berachain/offchain-sdk/types"
+	queuetypes "github.com/berachain/offchain-sdk/types/queue/types"
+)
+
+const (
+	defaultQueueConcurrency     = 1
+	defaultQueueEmptyDelay      = time.Second
+	defaultQueueMaxReceiveBatch = 10
+)
+
+// Compile time check to ensure that QueueConsumer implements job.HasProducer, and optionally the
+// basic job's Setup and Teardown methods.
+var (
+	_ job.HasProducer = (*QueueConsumer[queuetypes.Marshallable])(nil)
+	_ job.HasSetup    = (*QueueConsumer[queuetypes.Marshallable])(nil)
+	_ job.HasTeardown = (*QueueConsumer[queuetypes.Marshallable])(nil)
+)
+
+// QueueConsumerConfig configures how a QueueConsumer consumes messages from its queue.
+type QueueConsumerConfig struct {
+	// Concurrency is the max number of messages being executed at once. Defaults to 1, which
+	// executes messages one at a time, in the order they are received.
+	Concurrency int
+	// EmptyQueueDelay is how long to wait before receiving again if the queue is empty or the
+	// receive fails. Defaults to 1s.
+	EmptyQueueDelay time.Duration
+	// ReleaseOnFailure, if true, releases messages whose execution fails back to the queue to be
+	// redelivered immediately. Otherwise (the default), they are left in the queue and redelivered
+	// once their visibility timeout expires.
+	ReleaseOnFailure bool
+}
+
+// withDefaults returns the config with any unset fields set to their defaults.
+func (c QueueConsumerConfig) withDefaults() QueueConsumerConfig {
+	if c.Concurrency <= 0 {
+		c.Concurrency = defaultQueueConcurrency
+	}
+	if c.EmptyQueueDelay <= 0 {
+		c.EmptyQueueDelay = defaultQueueEmptyDelay
+	}
+	return c
+}
+
+// QueueConsumer allows you to feed messages from a queue to a basic job. Each message received is
+// passed as the input to the basic job's Execute, honoring its policy, and is deleted from the
+// queue only if the execution succeeds (after all attempts); otherwise it is redelivered by the
+// queue.
+type QueueConsumer[T queuetypes.Marshallable] struct {
+	job.Basic
+	queue queuetypes.QueueV2[T]
+	cfg   QueueConsumerConfig
+}
+
+// NewQueueConsumer creates a new QueueConsumer. Queues that implement queuetypes.Queue can be
+// adapted with queuetypes.AdaptQueue.
+func NewQueueConsumer[T queuetypes.Marshallable](
+	basic job.Basic, queue queuetypes.QueueV2[T], cfg QueueConsumerConfig,
+) *QueueConsumer[T] {
+	return &QueueConsumer[T]{
+		Basic: basic,
+		queue: queue,
+		cfg:   cfg.withDefaults(),
+	}
+}
+
+// Producer receives messages from the queue and submits their execution to the worker pool, with
+// at most the configured concurrency executing at once.
+func (j *QueueConsumer[T]) Producer(ctx context.Context, pool job.WorkerPool) error {
+	logger := sdk.UnwrapContext(ctx).Logger()
+	slots := make(chan struct{}, j.cfg.Concurrency)
+
+	for {
+		// Wait for at least one free slot, then claim as many more as are free.
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case slots <- struct{}{}:
+		}
+		free := int32(1)
+	claim:
+		for free < defaultQueueMaxReceiveBatch {
+			select {
+			case slots <- struct{}{}:
+				free++
+			default:
+				break claim
+			}
+		}
+
+		msgIDs, items, err := j.queue.ReceiveMany(ctx, free)
+		if err != nil && !errors.Is(err, queuetypes.ErrEmpty) && ctx.Err() == nil {
+			logger.Error("error receiving from queue", "job", j.RegistryKey(), "err", err)
+		}
+
+		// Return the slots that were not used.
+		for i := len(items); i < int(free); i++ {
+			<-slots
+		}
+
+		for i := range items {
+			msgID, item := msgIDs[i], items[i]
+			pool.Submit(func() {
+				defer func() { <-slots }()
+				j.consume(ctx, pool, msgID, item)
+			})
+		}
+
+		if len(items) == 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(j.cfg.EmptyQueueDelay):
+			}
+		}
+	}
+}
+
+// consume executes the basic job with the given message, like any other execution of the job, and
+// deletes the message only if the execution succeeds.
+func (j *QueueConsumer[T]) consume(
+	ctx context.Context, pool job.WorkerPool, msgID string, item T,
+) {
+	logger := sdk.UnwrapContext(ctx).Logger()
+
+	if err := job.NewPayload(ctx, pool, j, item).Run(); err != nil {
+		logger.Error("error executing queue message", "job", j.RegistryKey(), "msgID", msgID,
+			"err", err)
+		if !j.cfg.ReleaseOnFailure || ctx.Err() != nil {
+			return
+		}
+		if err = j.queue.Release(ctx, msgID); err != nil &&
+			!errors.Is(err, queuetypes.ErrReleaseNotSupported) {
+			logger.Error("error releasing queue message", "job", j.RegistryKey(), "msgID", msgID,
+				"err", err)
+		}
+		return
+	}
+
+	if err := j.queue.Delete(ctx, msgID); err != nil {
+		logger.Error("error deleting queue message", "job", j.RegistryKey(), "msgID", msgID,
+			"err", err)
+	}
+}
+
+// Unwrap implements jobtypes.Unwrapper, so that the basic job's policy and error handler are
+// honored.
+func (j *QueueConsumer[T]) Unwrap() jobtypes.Executable {
+	return j.Basic
+}
+
+func (j *QueueConsumer[T]) Setup(ctx context.Context) error {
+	if setupJob, ok := j.Basic.(job.HasSetup); ok {
+		return setupJob.Setup(ctx)
+	}
+	return nil
+}
+
+func (j *QueueConsumer[T]) Teardown() error {
+	if setupJob, ok := j.Basic.(job.HasTeardown); ok {
+		return setupJob.Teardown()
+	}
+	return nil
+}
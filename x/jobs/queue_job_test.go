@@ -0,0 +1,172 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/berachain/offchain-sdk/types/queue/mem"
+	queuetypes "github.com/berachain/offchain-sdk/types/queue/types"
+	"github.com/berachain/offchain-sdk/x/jobs"
+	"github.com/stretchr/testify/require"
+)
+
+type message struct {
+	ID string
+}
+
+func (m *message) String() string             { return m.ID }
+func (*message) New() queuetypes.Marshallable { return &message{} }
+func (*message) Marshal() ([]byte, error)     { return nil, nil }
+func (*message) Unmarshal([]byte) error       { return nil }
+
+// goPool runs every submitted task in its own goroutine.
+type goPool struct{}
+
+func (goPool) Submit(f func())        { go f() }
+func (goPool) SubmitAndWait(f func()) { f() }
+
+// flakyJob fails the first execution of every message, recording successful executions.
+type flakyJob struct {
+	mu        sync.Mutex
+	attempts  map[string]int
+	succeeded []string
+}
+
+func (*flakyJob) RegistryKey() string { return "flaky" }
+
+func (j *flakyJob) Execute(_ context.Context, args any) (any, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	id := args.(*message).ID
+	j.attempts[id]++
+	if j.attempts[id] == 1 {
+		return nil, errors.New("first attempt fails")
+	}
+	j.succeeded = append(j.succeeded, id)
+	return nil, nil //nolint:nilnil // test job.
+}
+
+func TestQueueConsumerRedeliversOnFailure(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sCtx := sdk.NewContext(ctx, nil, log.NewLogger(os.Stdout, "test-runner"), nil)
+
+	queue := mem.NewQueueV2[*message](0)
+	for _, id := range []string{"a", "b", "c"} {
+		_, err := queue.Push(ctx, &message{ID: id})
+		require.NoError(t, err)
+	}
+
+	basic := &flakyJob{attempts: make(map[string]int)}
+	consumer := jobs.NewQueueConsumer[*message](basic, queue, jobs.QueueConsumerConfig{
+		Concurrency:      2,
+		EmptyQueueDelay:  time.Millisecond,
+		ReleaseOnFailure: true,
+	})
+	go func() { _ = consumer.Producer(sCtx, goPool{}) }()
+
+	require.Eventually(t, func() bool {
+		basic.mu.Lock()
+		defer basic.mu.Unlock()
+		return len(basic.succeeded) == 3
+	}, time.Second, time.Millisecond)
+
+	// Every message was executed exactly twice: once failing, then redelivered and succeeding.
+	basic.mu.Lock()
+	defer basic.mu.Unlock()
+	require.ElementsMatch(t, []string{"a", "b", "c"}, basic.succeeded)
+	for _, attempts := range basic.attempts {
+		require.Equal(t, 2, attempts)
+	}
+	n, err := queue.Len(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+}
+
+func TestQueueConsumerRedeliversAfterVisibilityTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sCtx := sdk.NewContext(ctx, nil, log.NewLogger(os.Stdout, "test-runner"), nil)
+
+	queue := mem.NewQueueV2[*message](20 * time.Millisecond)
+	_, err := queue.Push(ctx, &message{ID: "a"})
+	require.NoError(t, err)
+
+	// Failed messages are not released, but redelivered once their visibility timeout expires.
+	basic := &flakyJob{attempts: make(map[string]int)}
+	consumer := jobs.NewQueueConsumer[*message](basic, queue, jobs.QueueConsumerConfig{
+		EmptyQueueDelay: time.Millisecond,
+	})
+	start := time.Now()
+	go func() { _ = consumer.Producer(sCtx, goPool{}) }()
+
+	require.Eventually(t, func() bool {
+		basic.mu.Lock()
+		defer basic.mu.Unlock()
+		return len(basic.succeeded) == 1
+	}, time.Second, time.Millisecond)
+	require.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+
+	basic.mu.Lock()
+	defer basic.mu.Unlock()
+	require.Equal(t, 2, basic.attempts["a"])
+}
+
+// retryingJob retries every failed execution of the flaky job once, without backoff.
+type retryingJob struct {
+	*flakyJob
+}
+
+func (retryingJob) Policy() jobtypes.Policy {
+	return jobtypes.Policy{MaxAttempts: 2, Backoff: func(int) time.Duration { return 0 }}
+}
+
+// observingPool is a goPool that observes the outcome of executions.
+type observingPool struct {
+	goPool
+	mu   sync.Mutex
+	errs []error
+}
+
+func (p *observingPool) ObserveExecution(_ jobtypes.Executable, _ time.Time, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.errs = append(p.errs, err)
+}
+
+func TestQueueConsumerHonorsPolicy(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sCtx := sdk.NewContext(ctx, nil, log.NewLogger(os.Stdout, "test-runner"), nil)
+
+	queue := mem.NewQueueV2[*message](time.Hour)
+	_, err := queue.Push(ctx, &message{ID: "a"})
+	require.NoError(t, err)
+
+	// The failed attempt is retried by the policy, without the message being redelivered.
+	basic := retryingJob{&flakyJob{attempts: make(map[string]int)}}
+	consumer := jobs.NewQueueConsumer[*message](basic, queue, jobs.QueueConsumerConfig{
+		EmptyQueueDelay: time.Millisecond,
+	})
+	pool := &observingPool{}
+	go func() { _ = consumer.Producer(sCtx, pool) }()
+
+	// The execution is observed once, after all attempts.
+	require.Eventually(t, func() bool {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		return len(pool.errs) == 1 && pool.errs[0] == nil
+	}, time.Second, time.Millisecond)
+
+	basic.mu.Lock()
+	defer basic.mu.Unlock()
+	require.Equal(t, 2, basic.attempts["a"])
+}
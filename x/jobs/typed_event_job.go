@@ -0,0 +1,151 @@
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// Compile time check to ensure that eventDecoder implements job.Typed, and the policy of the typed
+// handler it wraps, which it unwraps to.
+var (
+	_ job.Typed[coretypes.Log, any] = (*eventDecoder[any, any])(nil)
+	_ jobtypes.HasPolicy            = (*eventDecoder[any, any])(nil)
+	_ jobtypes.Unwrapper            = (*eventDecoder[any, any])(nil)
+)
+
+// rawField is the name of the field of event structs that is set to the log the event was decoded
+// from, as in abigen's event structs.
+const rawField = "Raw"
+
+// NewEventSub creates an event job that subscribes the given typed handler to the named event of
+// the contract with the given ABI (e.g. abigen's `<Contract>MetaData`), at the given address. Each
+// log is decoded into an E, which is either a struct (or pointer to a struct) whose fields are
+// named after the event's arguments, such as abigen's event structs, or a map[string]any. Struct
+// fields named Raw, of type coretypes.Log, are set to the log itself.
+//
+// The indexed filters restrict the subscription to the logs whose indexed arguments match, in the
+// order of the event's indexed arguments: each filter lists the values the argument may have, and
+// an empty filter matches any value.
+func NewEventSub[E, Out any](
+	handler job.Typed[E, Out], metadata *bind.MetaData, event string, address common.Address,
+	indexed ...[]any,
+) (*EthFilterSub, error) {
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	ev, ok := parsed.Events[event]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in ABI", event)
+	}
+
+	var numIndexed int
+	for _, arg := range ev.Inputs {
+		if arg.Indexed {
+			numIndexed++
+		}
+	}
+	if len(indexed) > numIndexed {
+		return nil, fmt.Errorf(
+			"event %s has %d indexed arguments, got %d filters", event, numIndexed, len(indexed),
+		)
+	}
+	topics, err := abi.MakeTopics(append([][]any{{ev.ID}}, indexed...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := &eventDecoder[E, Out]{
+		Typed:    handler,
+		event:    event,
+		contract: bind.NewBoundContract(address, *parsed, nil, nil, nil),
+	}
+	return NewEthFilterSub(job.WrapTyped[coretypes.Log, Out](decoder), ethereum.FilterQuery{
+		Addresses: []common.Address{address},
+		Topics:    topics,
+	}), nil
+}
+
+// eventDecoder is a typed job that decodes logs into events, before passing them on to the typed
+// handler it wraps.
+type eventDecoder[E, Out any] struct {
+	job.Typed[E, Out]
+	event    string
+	contract *bind.BoundContract
+}
+
+// Execute decodes the log into an E and executes the typed handler with it.
+func (d *eventDecoder[E, Out]) Execute(ctx context.Context, l coretypes.Log) (Out, error) {
+	event, err := d.decode(l)
+	if err != nil {
+		var zero Out
+		return zero, fmt.Errorf("job %s: decoding %s event: %w", d.RegistryKey(), d.event, err)
+	}
+	return d.Typed.Execute(ctx, event)
+}
+
+// decode decodes the given log into an E.
+func (d *eventDecoder[E, Out]) decode(l coretypes.Log) (E, error) {
+	var event E
+	if m, ok := any(&event).(*map[string]any); ok {
+		*m = make(map[string]any)
+		return event, d.contract.UnpackLogIntoMap(*m, d.event, l)
+	}
+
+	// Decode into the struct (allocating it, if E is a pointer).
+	out := reflect.ValueOf(&event)
+	if t := reflect.TypeOf(event); t != nil && t.Kind() == reflect.Pointer {
+		out.Elem().Set(reflect.New(t.Elem()))
+		out = out.Elem()
+	}
+	if err := d.contract.UnpackLog(out.Interface(), d.event, l); err != nil {
+		return event, err
+	}
+	if v := out.Elem(); v.Kind() == reflect.Struct {
+		if raw := v.FieldByName(rawField); raw.CanSet() && raw.Type() == reflect.TypeOf(l) {
+			raw.Set(reflect.ValueOf(l))
+		}
+	}
+	return event, nil
+}
+
+// Setup calls the typed handler's Setup, if it has one.
+func (d *eventDecoder[E, Out]) Setup(ctx context.Context) error {
+	if sj, ok := d.Typed.(interface{ Setup(context.Context) error }); ok {
+		return sj.Setup(ctx)
+	}
+	return nil
+}
+
+// Teardown calls the typed handler's Teardown, if it has one.
+func (d *eventDecoder[E, Out]) Teardown() error {
+	if tj, ok := d.Typed.(interface{ Teardown() error }); ok {
+		return tj.Teardown()
+	}
+	return nil
+}
+
+// Policy returns the typed handler's policy, if it has one.
+func (d *eventDecoder[E, Out]) Policy() jobtypes.Policy {
+	if pj, ok := d.Typed.(jobtypes.HasPolicy); ok {
+		return pj.Policy()
+	}
+	return jobtypes.Policy{}
+}
+
+// Unwrap implements jobtypes.Unwrapper, so that the typed handler's optional interfaces (e.g.
+// job.HasRevertHandler or job.HasReorgConfig) are honored. Revert events are passed to the
+// handler as is, without being decoded.
+func (d *eventDecoder[E, Out]) Unwrap() jobtypes.Executable {
+	return job.WrapTyped[E, Out](d.Typed)
+}
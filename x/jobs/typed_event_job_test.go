@@ -0,0 +1,134 @@
+package jobs_test
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/berachain/offchain-sdk/job"
+	jobtypes "github.com/berachain/offchain-sdk/job/types"
+	"github.com/berachain/offchain-sdk/log"
+	sdk "github.com/berachain/offchain-sdk/types"
+	"github.com/berachain/offchain-sdk/x/jobs"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var tokenMetaData = &bind.MetaData{
+	ABI: `[{"anonymous":false,"type":"event","name":"Transfer","inputs":[` +
+		`{"indexed":true,"name":"from","type":"address"},` +
+		`{"indexed":true,"name":"to","type":"address"},` +
+		`{"indexed":false,"name":"value","type":"uint256"}]}]`,
+}
+
+// transfer is the decoded Transfer event, as generated by abigen.
+type transfer struct {
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	Raw   coretypes.Log
+}
+
+// eventHandler is a typed handler that records the last event it handled.
+type eventHandler[E any] struct {
+	last E
+}
+
+func (*eventHandler[E]) RegistryKey() string { return "events" }
+
+func (h *eventHandler[E]) Execute(_ context.Context, event E) (any, error) {
+	h.last = event
+	return nil, nil //nolint:nilnil // test handler.
+}
+
+func TestEventSubDecodesLogs(t *testing.T) {
+	var (
+		token = common.HexToAddress("0x1")
+		from  = common.HexToAddress("0x2")
+		to    = common.HexToAddress("0x3")
+	)
+	parsed, err := tokenMetaData.GetAbi()
+	require.NoError(t, err)
+	event := parsed.Events["Transfer"]
+	data, err := event.Inputs.NonIndexed().Pack(big.NewInt(42))
+	require.NoError(t, err)
+	l := coretypes.Log{
+		Address:     token,
+		Topics:      []common.Hash{event.ID, common.BytesToHash(from[:]), common.BytesToHash(to[:])},
+		Data:        data,
+		BlockNumber: 7,
+	}
+
+	chain := &logChain{}
+	sCtx := sdk.NewContext(
+		context.Background(), chain, log.NewLogger(os.Stdout, "test-runner"), nil,
+	)
+
+	// Logs are decoded into structs, filtered by the given indexed arguments.
+	structs := &eventHandler[*transfer]{}
+	sub, err := jobs.NewEventSub[*transfer, any](
+		structs, tokenMetaData, "Transfer", token, nil, []any{to},
+	)
+	require.NoError(t, err)
+	_, _, err = sub.Subscribe(sCtx)
+	require.NoError(t, err)
+	sub.Unsubscribe(sCtx)
+	require.Equal(t,
+		[][]common.Hash{{event.ID}, nil, {common.BytesToHash(to[:])}}, chain.query.Topics)
+
+	_, err = sub.Execute(sCtx, l)
+	require.NoError(t, err)
+	require.Equal(t, &transfer{From: from, To: to, Value: big.NewInt(42), Raw: l}, structs.last)
+
+	// Or into maps.
+	maps := &eventHandler[map[string]any]{}
+	mapSub, err := jobs.NewEventSub[map[string]any, any](maps, tokenMetaData, "Transfer", token)
+	require.NoError(t, err)
+	_, err = mapSub.Execute(sCtx, l)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"from": from, "to": to, "value": big.NewInt(42)}, maps.last)
+
+	// Too many indexed filters are rejected.
+	_, err = jobs.NewEventSub[transfer, any](
+		&eventHandler[transfer]{}, tokenMetaData, "Transfer", token, nil, nil, nil,
+	)
+	require.Error(t, err)
+}
+
+// revertingHandler is a typed handler that handles reorgs, recording the events it reverted.
+type revertingHandler struct {
+	eventHandler[*transfer]
+	reverted []any
+}
+
+func (*revertingHandler) ReorgConfig() job.ReorgConfig {
+	return job.ReorgConfig{FinalityDepth: 2}
+}
+
+func (h *revertingHandler) Revert(_ context.Context, event any) error {
+	h.reverted = append(h.reverted, event)
+	return nil
+}
+
+func TestEventSubHonorsHandlerReorgs(t *testing.T) {
+	handler := &revertingHandler{}
+	sub, err := jobs.NewEventSub[*transfer, any](
+		handler, tokenMetaData, "Transfer", common.HexToAddress("0x1"),
+	)
+	require.NoError(t, err)
+
+	// The typed handler's reorg config and revert handler are found through the event job.
+	hrc, ok := jobtypes.As[job.HasReorgConfig](sub)
+	require.True(t, ok)
+	require.Equal(t, uint64(2), hrc.ReorgConfig().FinalityDepth)
+
+	rh, ok := jobtypes.As[job.HasRevertHandler](sub)
+	require.True(t, ok)
+	removed := job.LogRemoved{Log: coretypes.Log{BlockNumber: 7, Removed: true}}
+	require.NoError(t, rh.Revert(context.Background(), removed))
+	require.Equal(t, []any{removed}, handler.reverted)
+}